@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/charmbracelet/fang"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 	"go.dalton.dog/prism/internal"
 )
@@ -13,6 +14,16 @@ var rootCmd = &cobra.Command{
 	Use:   "prism",
 	Short: "Prism is a wrapper around go test to make it simple and beautiful",
 	Args:  cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		internal.GlobalConfig.FormatExplicit = cmd.Flags().Changed("format")
+
+		theme, err := internal.LoadThemeConfig(internal.GlobalConfig.ConfigPath)
+		if err != nil {
+			return err
+		}
+		internal.ApplyTheme(theme)
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Execute(args)
 	},
@@ -28,4 +39,18 @@ func init() {
 	internal.GlobalConfig = internal.Config{}
 	rootCmd.PersistentFlags().BoolVarP(&internal.GlobalConfig.Verbose, "verbose", "v", false, "Include test sub-output")
 	rootCmd.PersistentFlags().BoolVarP(&internal.GlobalConfig.OnlyFails, "only-fails", "f", false, "Only run failing tests")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.JunitFile, "junitfile", os.Getenv("PRISM_JUNITFILE"), "Write a JUnit XML report to this path (env: PRISM_JUNITFILE)")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.JunitTestcaseClassname, "junitfile-testcase-classname", "full", "JUnit testcase classname format: short, relative, or full")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.Format, "format", "pretty", "Output format: pretty, dots, silent, testname, standard-quiet, standard-verbose, or github-actions")
+	rootCmd.PersistentFlags().IntVar(&internal.GlobalConfig.RerunFails, "rerun-fails", 0, "Rerun failed tests up to N times, marking ones that later pass as flaky")
+	rootCmd.PersistentFlags().IntVar(&internal.GlobalConfig.RerunFailsMaxFailures, "rerun-fails-max-failures", 0, "Skip reruns if more than M tests failed (0 means unlimited)")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.RerunFailsReport, "rerun-fails-report", "", "Write the list of flaky tests (pkg.TestName, one per line) to this path")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.LogLevel, "log-level", "warn", "Pipeline log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().BoolVar(&internal.GlobalConfig.LogJSON, "log-json", false, "Emit pipeline logs as JSON instead of text")
+	rootCmd.PersistentFlags().StringVar(&internal.GlobalConfig.ConfigPath, "config", "", "Path to a theme config file (default: ~/.config/prism/config.yaml)")
+
+	isTTY := term.IsTerminal(os.Stdout.Fd())
+	rootCmd.PersistentFlags().BoolVar(&internal.GlobalConfig.Watch, "watch", isTTY, "Stream a live Bubble Tea TUI as tests run (default: on when stdout is a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&internal.GlobalConfig.Watch, "live", isTTY, "Alias for --watch")
+	rootCmd.PersistentFlags().MarkHidden("live")
 }
@@ -0,0 +1,123 @@
+package internal
+
+import "go.dalton.dog/prism/internal/reporter"
+
+// appendToPackage folds a finished TestResult into its package's running
+// PackageResults, creating the entry on first sight.
+func appendToPackage(pkgAgg map[string]*PackageResults, result TestResult) {
+	pkg, ok := pkgAgg[result.Package]
+	if !ok {
+		pkg = &PackageResults{Name: result.Package, Status: StatusPass}
+		pkgAgg[result.Package] = pkg
+	}
+
+	pkg.Tests = append(pkg.Tests, result)
+	pkg.Total++
+	pkg.Duration += result.Duration
+
+	switch result.Status {
+	case StatusPass:
+		pkg.Passed++
+	case StatusFail:
+		pkg.Failed++
+		pkg.Status = StatusFail
+	case StatusSkip:
+		pkg.Skipped++
+		if pkg.Status == StatusPass && pkg.Skipped == pkg.Total {
+			pkg.Status = StatusSkip
+		}
+	}
+}
+
+// finishPackage reacts to a package's own terminal action: it synthesizes a
+// [build]/[setup] failure when the package failed but none of its tests
+// did (a compile error or TestMain panic), then hands the completed package
+// off to the reporter.
+func finishPackage(
+	pkgName string,
+	final Status,
+	pkgAgg map[string]*PackageResults,
+	summary *TestSummary,
+	rep reporter.Reporter,
+) {
+	pkg, ok := pkgAgg[pkgName]
+	if !ok {
+		pkg = &PackageResults{Name: pkgName, Status: StatusPass}
+		pkgAgg[pkgName] = pkg
+	}
+
+	if final == StatusFail && pkg.Failed == 0 {
+		label := "[setup]"
+		if pkg.Total == 0 {
+			label = "[build]"
+		}
+
+		synthetic := TestResult{
+			Name:    label,
+			Package: pkgName,
+			Status:  StatusFail,
+			Output:  summary.PackageEvents[pkgName],
+		}
+
+		pkg.Tests = append(pkg.Tests, synthetic)
+		pkg.Total++
+		pkg.Failed++
+		pkg.Status = StatusFail
+
+		summary.Results = append(summary.Results, synthetic)
+		summary.Failed++
+		summary.Total++
+	}
+
+	rep.PackageFinished(toReporterPackage(pkg))
+}
+
+func toReporterResult(r TestResult) reporter.TestResult {
+	return reporter.TestResult{
+		Name:        r.Name,
+		Package:     r.Package,
+		Status:      reporter.Status(r.Status),
+		Duration:    r.Duration,
+		Output:      r.Output,
+		Attempts:    r.Attempts,
+		FlakyPassed: r.FlakyPassed,
+	}
+}
+
+func toReporterPackage(p *PackageResults) reporter.PackageResult {
+	tests := make([]reporter.TestResult, len(p.Tests))
+	for i, t := range p.Tests {
+		tests[i] = toReporterResult(t)
+	}
+	return reporter.PackageResult{
+		Name:     p.Name,
+		Tests:    tests,
+		Status:   reporter.Status(p.Status),
+		Total:    p.Total,
+		Passed:   p.Passed,
+		Failed:   p.Failed,
+		Skipped:  p.Skipped,
+		Flaky:    p.Flaky,
+		Duration: p.Duration,
+	}
+}
+
+func toReporterSummary(s *TestSummary) reporter.Summary {
+	results := make([]reporter.TestResult, len(s.Results))
+	var flaky []reporter.TestResult
+	for i, r := range s.Results {
+		rr := toReporterResult(r)
+		results[i] = rr
+		if r.FlakyPassed {
+			flaky = append(flaky, rr)
+		}
+	}
+	return reporter.Summary{
+		Results: results,
+		Passed:  s.Passed,
+		Failed:  s.Failed,
+		Skipped: s.Skipped,
+		Total:   s.Total,
+		Flaky:   flaky,
+	}
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"sort"
+
+	"go.dalton.dog/prism/internal/junitxml"
+)
+
+// writeJUnitReport groups summary.Results by package and saves them as
+// JUnit XML to GlobalConfig.JunitFile. It's a no-op if that path is empty.
+func writeJUnitReport(summary *TestSummary) error {
+	if GlobalConfig.JunitFile == "" {
+		return nil
+	}
+
+	grouped := make(map[string][]junitxml.Case)
+	for _, result := range summary.Results {
+		grouped[result.Package] = append(grouped[result.Package], junitxml.Case{
+			Package:  result.Package,
+			Name:     result.Name,
+			Status:   string(result.Status),
+			Duration: result.Duration,
+			Output:   result.Output,
+		})
+	}
+
+	pkgNames := make([]string, 0, len(grouped))
+	for pkg := range grouped {
+		pkgNames = append(pkgNames, pkg)
+	}
+	sort.Strings(pkgNames)
+
+	suites := make([]junitxml.Suite, 0, len(pkgNames))
+	for _, pkg := range pkgNames {
+		suites = append(suites, junitxml.Suite{Package: pkg, Cases: grouped[pkg]})
+	}
+
+	return junitxml.Write(GlobalConfig.JunitFile, suites, junitxml.WithClassnameFormat(classnameFormat()))
+}
+
+// modulePath is this project's own module path, used to resolve the
+// "relative" --junitfile-testcase-classname option.
+const modulePath = "go.dalton.dog/prism"
+
+// classnameFormat maps --junitfile-testcase-classname to the junitxml
+// FormatFunc it names.
+func classnameFormat() junitxml.FormatFunc {
+	switch GlobalConfig.JunitTestcaseClassname {
+	case "short":
+		return junitxml.ShortPackageName()
+	case "relative":
+		return junitxml.RelativeToModule(modulePath)
+	default:
+		return junitxml.FullImportPath()
+	}
+}
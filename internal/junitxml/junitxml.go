@@ -0,0 +1,180 @@
+// Package junitxml renders a JUnit-compatible XML report from a flat list
+// of test suites, independent of how those suites were aggregated. It has
+// no dependency on the internal package so it can be reused (or replaced)
+// without risking an import cycle.
+package junitxml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Case is a single reported test, decoupled from internal.TestResult so
+// this package stays independently testable.
+type Case struct {
+	Package  string
+	Name     string
+	Status   string // "pass", "fail", "skip"
+	Duration time.Duration
+	Output   []string
+}
+
+// Suite groups every Case belonging to a single Go package.
+type Suite struct {
+	Package string
+	Cases   []Case
+}
+
+// FormatFunc derives the JUnit `classname` attribute from a Go package
+// import path, so output can match whatever convention a CI dashboard
+// already expects.
+type FormatFunc func(pkg string) string
+
+// FullImportPath uses the package's full import path as the classname,
+// unmodified. It's the default when no FormatFunc is supplied.
+func FullImportPath() FormatFunc {
+	return func(pkg string) string { return pkg }
+}
+
+// RelativeToModule strips modulePath (and a leading slash) from the
+// package path, so "go.dalton.dog/prism/internal" under module
+// "go.dalton.dog/prism" becomes "internal".
+func RelativeToModule(modulePath string) FormatFunc {
+	return func(pkg string) string {
+		rel := strings.TrimPrefix(pkg, modulePath)
+		rel = strings.TrimPrefix(rel, "/")
+		if rel == "" {
+			return pkg
+		}
+		return rel
+	}
+}
+
+// ShortPackageName uses only the last path segment of the package's import
+// path as the classname, so "go.dalton.dog/prism/internal" becomes
+// "internal".
+func ShortPackageName() FormatFunc {
+	return func(pkg string) string {
+		idx := strings.LastIndex(pkg, "/")
+		if idx == -1 {
+			return pkg
+		}
+		return pkg[idx+1:]
+	}
+}
+
+// Option configures Write.
+type Option func(*options)
+
+type options struct {
+	classname FormatFunc
+}
+
+// WithClassnameFormat overrides how package import paths are rendered as
+// the JUnit `classname` attribute.
+func WithClassnameFormat(f FormatFunc) Option {
+	return func(o *options) { o.classname = f }
+}
+
+// --- XML schema (encoding/xml target types) ---
+
+type xmlTestSuites struct {
+	XMLName xml.Name       `xml:"testsuites"`
+	Suites  []xmlTestSuite `xml:"testsuite"`
+}
+
+type xmlTestSuite struct {
+	XMLName  xml.Name      `xml:"testsuite"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Skipped  int           `xml:"skipped,attr"`
+	Time     string        `xml:"time,attr"`
+	Cases    []xmlTestCase `xml:"testcase"`
+}
+
+type xmlTestCase struct {
+	XMLName   xml.Name    `xml:"testcase"`
+	Classname string      `xml:"classname,attr"`
+	Name      string      `xml:"name,attr"`
+	Time      string      `xml:"time,attr"`
+	Failure   *xmlFailure `xml:"failure,omitempty"`
+	Skipped   *xmlSkipped `xml:"skipped,omitempty"`
+}
+
+type xmlFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type xmlSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Write renders suites as JUnit XML and saves it to path, creating or
+// truncating the file as needed.
+func Write(path string, suites []Suite, opts ...Option) error {
+	cfg := options{classname: FullImportPath()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	doc := xmlTestSuites{}
+	for _, suite := range suites {
+		xs := xmlTestSuite{
+			Name: suite.Package,
+		}
+		var total time.Duration
+		for _, c := range suite.Cases {
+			xs.Tests++
+			total += c.Duration
+
+			xc := xmlTestCase{
+				Classname: cfg.classname(suite.Package),
+				Name:      c.Name,
+				Time:      fmt.Sprintf("%.3f", c.Duration.Seconds()),
+			}
+
+			switch c.Status {
+			case "fail":
+				xs.Failures++
+				xc.Failure = &xmlFailure{
+					Message: "Failed",
+					Body:    strings.Join(c.Output, "\n"),
+				}
+			case "skip":
+				xs.Skipped++
+				xc.Skipped = &xmlSkipped{
+					Message: strings.Join(c.Output, "\n"),
+				}
+			}
+
+			xs.Cases = append(xs.Cases, xc)
+		}
+		xs.Time = fmt.Sprintf("%.3f", total.Seconds())
+		doc.Suites = append(doc.Suites, xs)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal junit xml: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create junit report file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return fmt.Errorf("failed to write junit report file: %w", err)
+	}
+	if _, err := f.Write(out); err != nil {
+		return fmt.Errorf("failed to write junit report file: %w", err)
+	}
+
+	return nil
+}
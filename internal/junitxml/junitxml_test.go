@@ -0,0 +1,119 @@
+package junitxml
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFullImportPath(t *testing.T) {
+	format := FullImportPath()
+	if got := format("go.dalton.dog/prism/internal"); got != "go.dalton.dog/prism/internal" {
+		t.Errorf("FullImportPath()(%q) = %q, want unchanged", "go.dalton.dog/prism/internal", got)
+	}
+}
+
+func TestRelativeToModule(t *testing.T) {
+	cases := []struct {
+		pkg  string
+		want string
+	}{
+		{"go.dalton.dog/prism/internal", "internal"},
+		{"go.dalton.dog/prism", "go.dalton.dog/prism"}, // the module root itself has no relative path
+		{"go.dalton.dog/other/internal", "go.dalton.dog/other/internal"},
+	}
+
+	format := RelativeToModule("go.dalton.dog/prism")
+	for _, c := range cases {
+		if got := format(c.pkg); got != c.want {
+			t.Errorf("RelativeToModule(%q)(%q) = %q, want %q", "go.dalton.dog/prism", c.pkg, got, c.want)
+		}
+	}
+}
+
+func TestShortPackageName(t *testing.T) {
+	cases := []struct {
+		pkg  string
+		want string
+	}{
+		{"go.dalton.dog/prism/internal", "internal"},
+		{"go.dalton.dog/prism/internal/reporter", "reporter"},
+		{"prism", "prism"}, // no slash at all: returned unchanged
+	}
+
+	format := ShortPackageName()
+	for _, c := range cases {
+		if got := format(c.pkg); got != c.want {
+			t.Errorf("ShortPackageName()(%q) = %q, want %q", c.pkg, got, c.want)
+		}
+	}
+}
+
+func TestWriteWithClassnameFormat(t *testing.T) {
+	suites := []Suite{
+		{
+			Package: "go.dalton.dog/prism/internal",
+			Cases: []Case{
+				{Package: "go.dalton.dog/prism/internal", Name: "TestPass", Status: "pass"},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.xml")
+	if err := Write(path, suites, WithClassnameFormat(ShortPackageName())); err != nil {
+		t.Fatalf("Write returned an unexpected error: %v", err)
+	}
+
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written report: %v", err)
+	}
+	if !strings.Contains(string(out), `classname="internal"`) {
+		t.Errorf("expected WithClassnameFormat(ShortPackageName()) to override the classname, got: %s", out)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	suites := []Suite{
+		{
+			Package: "go.dalton.dog/prism/internal",
+			Cases: []Case{
+				{Package: "go.dalton.dog/prism/internal", Name: "TestPass", Status: "pass", Duration: 10 * time.Millisecond},
+				{Package: "go.dalton.dog/prism/internal", Name: "TestFail", Status: "fail", Duration: 5 * time.Millisecond, Output: []string{"expected 1, got 2"}},
+				{Package: "go.dalton.dog/prism/internal", Name: "TestSkip", Status: "skip", Duration: 0, Output: []string{"not implemented yet"}},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.xml")
+	if err := Write(path, suites); err != nil {
+		t.Fatalf("Write returned an unexpected error: %v", err)
+	}
+
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written report: %v", err)
+	}
+	report := string(out)
+
+	if !strings.Contains(report, `tests="3"`) {
+		t.Errorf("expected tests=\"3\", got: %s", report)
+	}
+	if !strings.Contains(report, `failures="1"`) {
+		t.Errorf("expected failures=\"1\", got: %s", report)
+	}
+	if !strings.Contains(report, `skipped="1"`) {
+		t.Errorf("expected skipped=\"1\", got: %s", report)
+	}
+	if !strings.Contains(report, `classname="go.dalton.dog/prism/internal"`) {
+		t.Errorf("expected the default FullImportPath classname, got: %s", report)
+	}
+	if !strings.Contains(report, "expected 1, got 2") {
+		t.Errorf("expected the failure body to contain the test's output, got: %s", report)
+	}
+	if !strings.Contains(report, "not implemented yet") {
+		t.Errorf("expected the skipped message to contain the test's output, got: %s", report)
+	}
+}
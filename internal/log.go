@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Logger is prism's package-level structured logger for pipeline-internal
+// events: malformed test2json lines, scanner errors, unexpected exit codes.
+// It's separate from the colorized messages Execute prints for CLI users.
+// Defaults to a text handler on stderr at LevelWarn. Execute rebuilds it
+// from --log-level/--log-json when it starts (see configureLogger), which
+// replaces any logger installed with SetLogger before that call.
+var Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+// SetLogger replaces Logger with l. Execute's own startup call to
+// configureLogger overwrites it again.
+func SetLogger(l *slog.Logger) {
+	Logger = l
+}
+
+// configureLogger rebuilds Logger from GlobalConfig.LogLevel/LogJSON. Execute
+// calls this once at startup so --log-level/--log-json take effect without
+// embedders having to wire them up themselves.
+func configureLogger(w io.Writer) {
+	opts := &slog.HandlerOptions{Level: parseLogLevel(GlobalConfig.LogLevel)}
+
+	var handler slog.Handler
+	if GlobalConfig.LogJSON {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	Logger = slog.New(handler)
+}
+
+func parseLogLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
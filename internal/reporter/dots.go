@@ -0,0 +1,45 @@
+package reporter
+
+import "fmt"
+
+// Dots streams a single colored character per test as results arrive: a
+// green '.' for a pass, red 'F' for a fail, yellow 's' for a skip. It
+// flushes immediately rather than waiting for the run to finish.
+type Dots struct {
+	count int
+}
+
+// NewDots builds the compact one-character-per-test reporter.
+func NewDots() *Dots { return &Dots{} }
+
+func (d *Dots) TestStarted(pkg, name string) {}
+
+func (d *Dots) TestFinished(result TestResult) {
+	var ch string
+	switch result.Status {
+	case StatusPass:
+		ch = passStyle.Render(".")
+	case StatusFail:
+		ch = failStyle.Render("F")
+	case StatusSkip:
+		ch = skipStyle.Render("s")
+	default:
+		return
+	}
+
+	fmt.Print(ch)
+	d.count++
+	if d.count%80 == 0 {
+		fmt.Println()
+	}
+}
+
+func (d *Dots) PackageFinished(pkg PackageResult) {}
+
+func (d *Dots) RunFinished(summary Summary) {
+	if d.count%80 != 0 {
+		fmt.Println()
+	}
+	fmt.Println()
+	fmt.Println(RenderOverallSummary(summary))
+}
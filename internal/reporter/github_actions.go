@@ -0,0 +1,56 @@
+package reporter
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// failureLocationRe matches the "file.go:123: message" line `t.Errorf`/
+// `t.Fatalf` produce, which go test indents under the failing test.
+var failureLocationRe = regexp.MustCompile(`^\s*([\w./-]+\.go):(\d+):\s*(.*)$`)
+
+// GithubActions emits GitHub Actions error-annotation lines
+// (`::error file=…,line=…::message`) for each failed test, so failures
+// surface inline on the PR diff instead of only in the raw log.
+type GithubActions struct{}
+
+// NewGithubActions builds the GitHub Actions annotation reporter.
+func NewGithubActions() *GithubActions { return &GithubActions{} }
+
+func (g *GithubActions) TestStarted(pkg, name string) {}
+
+func (g *GithubActions) TestFinished(result TestResult) {
+	if result.Status != StatusFail {
+		return
+	}
+
+	title := fmt.Sprintf("%s.%s", result.Package, result.Name)
+	file, line, message := parseFailureLocation(result.Output)
+	if file == "" {
+		fmt.Printf("::error title=%s::%s\n", title, message)
+		return
+	}
+	fmt.Printf("::error file=%s,line=%s,title=%s::%s\n", file, line, title, message)
+}
+
+func (g *GithubActions) PackageFinished(pkg PackageResult) {}
+
+func (g *GithubActions) RunFinished(summary Summary) {
+	fmt.Println(RenderOverallSummary(summary))
+}
+
+// parseFailureLocation finds the first "file.go:line: message" line in a
+// failed test's captured output, if any. Falling back to the last captured
+// line keeps the annotation non-empty even when output doesn't match the
+// usual t.Errorf/t.Fatalf shape.
+func parseFailureLocation(output []string) (file, line, message string) {
+	for _, l := range output {
+		if m := failureLocationRe.FindStringSubmatch(l); m != nil {
+			return m[1], m[2], m[3]
+		}
+	}
+	if len(output) > 0 {
+		message = output[len(output)-1]
+	}
+	return "", "", message
+}
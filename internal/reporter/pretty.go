@@ -0,0 +1,196 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+)
+
+// --- Lipgloss styles (kept local so this package has no dependency on
+// internal/styles.go) ---
+var (
+	passStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#8AFF8A")).Bold(true)
+	failStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF8A80")).Bold(true)
+	skipStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFAA")).Bold(true)
+	flakyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Bold(true)
+
+	packageStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#8AFFFB")).Bold(true)
+	testNameStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E0E0E0"))
+	durationStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A0A0A0"))
+	outputStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#C0C0C0")).Italic(true).MarginLeft(3)
+
+	packageSeparatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#444444")).Faint(true)
+
+	pkgTableStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Align(lipgloss.Center)
+)
+
+// Status glyphs, overridable via Theme.
+var (
+	passGlyph    = "✓"
+	failGlyph    = "✗"
+	skipGlyph    = "⊝"
+	flakyGlyph   = "↻"
+	runningGlyph = "◌"
+
+	runningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#B0B0B0"))
+)
+
+func statusLabel(s Status) string {
+	var icon string
+	var style lipgloss.Style
+	switch s {
+	case StatusPass:
+		icon, style = passGlyph, passStyle
+	case StatusFail:
+		icon, style = failGlyph, failStyle
+	case StatusSkip:
+		icon, style = skipGlyph, skipStyle
+	case StatusFlaky:
+		icon, style = flakyGlyph, flakyStyle
+	default:
+		icon, style = runningGlyph, runningStyle
+	}
+	return style.Render(fmt.Sprintf("%s %s", icon, strings.ToUpper(string(s))))
+}
+
+// Pretty is the original table-style renderer: it prints each package's
+// block as soon as that package finishes, then, if more than one package
+// finished, the overall summary once the run completes.
+type Pretty struct {
+	// Verbose, when true, includes captured output for failed tests.
+	Verbose bool
+
+	packageCount int
+}
+
+// NewPretty builds the default lipgloss block renderer.
+func NewPretty() *Pretty { return &Pretty{} }
+
+func (p *Pretty) TestStarted(pkg, name string)   {}
+func (p *Pretty) TestFinished(result TestResult) {}
+
+func (p *Pretty) PackageFinished(pkg PackageResult) {
+	p.packageCount++
+	fmt.Println(RenderPackageBlock(pkg, p.Verbose))
+	fmt.Println()
+}
+
+func (p *Pretty) RunFinished(summary Summary) {
+	if p.packageCount > 1 {
+		fmt.Println(RenderOverallSummary(summary))
+	}
+}
+
+// RenderPackageBlock builds the display string for a single package. It's
+// exported so other formats (and tests) can reuse the table layout.
+func RenderPackageBlock(pkgResults PackageResult, verbose bool) string {
+	pkgHeader := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		statusLabel(pkgResults.Status),
+		" ",
+		packageStyle.Render(pkgResults.Name),
+		" ",
+		durationStyle.Render(fmt.Sprintf("(%v)", pkgResults.Duration)),
+	)
+
+	stats := fmt.Sprintf(
+		"%d total • %s • %s • %s",
+		pkgResults.Total,
+		passStyle.Render(fmt.Sprintf("%d passed", pkgResults.Passed)),
+		failStyle.Render(fmt.Sprintf("%d failed", pkgResults.Failed)),
+		skipStyle.Render(fmt.Sprintf("%d skipped", pkgResults.Skipped)),
+	)
+	if pkgResults.Flaky > 0 {
+		stats += " • " + flakyStyle.Render(fmt.Sprintf("%d flaky", pkgResults.Flaky))
+	}
+	pkgHeader = lipgloss.JoinVertical(lipgloss.Left, pkgHeader, stats)
+
+	tests := append([]TestResult(nil), pkgResults.Tests...)
+	sort.Slice(tests, func(i, j int) bool {
+		statusOrder := map[Status]int{
+			StatusFail:    4,
+			StatusFlaky:   3,
+			StatusSkip:    2,
+			StatusPass:    1,
+			StatusRunning: 0,
+		}
+		orderI := statusOrder[tests[i].Status]
+		orderJ := statusOrder[tests[j].Status]
+
+		if orderI != orderJ {
+			return orderI < orderJ
+		}
+		nameI := strings.TrimPrefix(tests[i].Name, "Test")
+		nameJ := strings.TrimPrefix(tests[j].Name, "Test")
+		return nameI < nameJ
+	})
+
+	t := table.New().
+		Border(lipgloss.HiddenBorder()).
+		Headers("RESULT", "DUR", "TEST").
+		Rows(renderTestRows(tests, verbose)...)
+
+	tableStr := t.Render()
+
+	separatorLine := packageSeparatorStyle.Render(strings.Repeat("─", max(lipgloss.Width(tableStr), lipgloss.Width(pkgHeader))))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		pkgHeader,
+		separatorLine,
+		pkgTableStyle.Render(tableStr),
+	)
+}
+
+// renderTestRows creates the rows for the lipgloss table, interleaving
+// output lines for failed tests when verbose is set.
+func renderTestRows(tests []TestResult, verbose bool) [][]string {
+	rows := make([][]string, 0)
+	for _, result := range tests {
+		displayTestName := strings.TrimPrefix(result.Name, "Test")
+
+		row := []string{
+			statusLabel(result.Status),
+			durationStyle.Render(fmt.Sprintf("%v", result.Duration)),
+			testNameStyle.Render(displayTestName),
+		}
+		rows = append(rows, row)
+
+		if result.Status == StatusFail && len(result.Output) > 0 && verbose {
+			for _, line := range result.Output {
+				if strings.TrimSpace(line) != "" && !(strings.HasPrefix(line, "===") || strings.HasPrefix(line, "---")) {
+					rows = append(rows, []string{"", "", outputStyle.Render(line)})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// RenderOverallSummary builds the display string for the final summary box.
+func RenderOverallSummary(summary Summary) string {
+	out := "Overall Test Results\n"
+	out += fmt.Sprintf(
+		"%d total • %s • %s • %s",
+		summary.Total,
+		passStyle.Render(fmt.Sprintf("%d passed", summary.Passed)),
+		failStyle.Render(fmt.Sprintf("%d failed", summary.Failed)),
+		skipStyle.Render(fmt.Sprintf("%d skipped", summary.Skipped)),
+	)
+	if len(summary.Flaky) > 0 {
+		out += " • " + flakyStyle.Render(fmt.Sprintf("%d flaky", len(summary.Flaky)))
+	}
+
+	if len(summary.Flaky) > 0 {
+		names := make([]string, len(summary.Flaky))
+		for i, t := range summary.Flaky {
+			names[i] = fmt.Sprintf("%s/%s", t.Package, t.Name)
+		}
+		out += "\n" + flakyStyle.Render(fmt.Sprintf("%d flaky (passed on retry):", len(summary.Flaky)))
+		out += "\n" + strings.Join(names, "\n")
+	}
+
+	return pkgTableStyle.Padding(1).Render(out)
+}
@@ -0,0 +1,107 @@
+// Package reporter defines the Reporter interface that prism's test
+// pipeline streams events into as they arrive, plus the built-in
+// implementations selectable via --format. It has no dependency on the
+// internal package so it can be reused (or replaced) without risking an
+// import cycle with the parser that feeds it.
+package reporter
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status mirrors internal.Status without importing internal.
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusFail    Status = "fail"
+	StatusSkip    Status = "skip"
+	StatusFlaky   Status = "flaky"
+	StatusRunning Status = "running"
+)
+
+// TestResult is a finalized test, decoupled from internal.TestResult.
+type TestResult struct {
+	Name     string
+	Package  string
+	Status   Status
+	Duration time.Duration
+	Output   []string
+
+	// Attempts and FlakyPassed reflect --rerun-fails: Attempts is 1 unless
+	// the test was retried, and FlakyPassed is true if it failed before
+	// eventually passing.
+	Attempts    int
+	FlakyPassed bool
+}
+
+// PackageResult aggregates every TestResult belonging to one Go package.
+type PackageResult struct {
+	Name    string
+	Tests   []TestResult
+	Status  Status
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+	// Flaky counts tests in this package that failed on an earlier attempt
+	// but passed under --rerun-fails. Counted separately from Passed so a
+	// package's flake rate doesn't get buried in its pass count.
+	Flaky    int
+	Duration time.Duration
+}
+
+// Summary is the overall result of a full test run.
+type Summary struct {
+	Results []TestResult
+	Passed  int
+	Failed  int
+	Skipped int
+	Total   int
+
+	// Flaky lists tests that failed on an earlier attempt but passed under
+	// --rerun-fails. They're counted in Total but not in Passed or Failed,
+	// and called out separately so a "green" run doesn't hide that it took
+	// retries.
+	Flaky []TestResult
+}
+
+// Reporter receives pipeline events as they stream in from `go test -json`,
+// rather than only after the whole run has finished.
+type Reporter interface {
+	// TestStarted fires the moment a test's "run" action is seen.
+	TestStarted(pkg, name string)
+	// TestFinished fires once a test reaches a terminal status.
+	TestFinished(result TestResult)
+	// PackageFinished fires once a package's own final action arrives.
+	PackageFinished(pkg PackageResult)
+	// RunFinished fires once the whole `go test` invocation has exited.
+	RunFinished(summary Summary)
+}
+
+// New resolves a --format name to a Reporter implementation. An empty
+// format falls back to "pretty".
+func New(format string) (Reporter, error) {
+	switch format {
+	case "", "pretty":
+		return NewPretty(), nil
+	case "dots":
+		return NewDots(), nil
+	case "silent":
+		return NewSilent(), nil
+	case "testname":
+		return NewTestname(), nil
+	case "standard-quiet":
+		return NewStandardQuiet(), nil
+	case "standard-verbose":
+		return NewStandardVerbose(), nil
+	case "github-actions":
+		return NewGithubActions(), nil
+	default:
+		return nil, fmt.Errorf(
+			"unknown format %q (want pretty, dots, silent, testname, standard-quiet, standard-verbose, or github-actions)",
+			format,
+		)
+	}
+}
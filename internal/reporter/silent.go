@@ -0,0 +1,18 @@
+package reporter
+
+import "fmt"
+
+// Silent suppresses all streaming output and prints only the final summary
+// box, useful for CI logs that only care about the end result.
+type Silent struct{}
+
+// NewSilent builds the summary-only reporter.
+func NewSilent() *Silent { return &Silent{} }
+
+func (s *Silent) TestStarted(pkg, name string)      {}
+func (s *Silent) TestFinished(result TestResult)    {}
+func (s *Silent) PackageFinished(pkg PackageResult) {}
+
+func (s *Silent) RunFinished(summary Summary) {
+	fmt.Println(RenderOverallSummary(summary))
+}
@@ -0,0 +1,73 @@
+package reporter
+
+import "fmt"
+
+// StandardVerbose mimics `go test -v`'s own per-test lines: "=== RUN
+// pkg.Test" as it starts, "--- PASS: pkg.Test (12ms)" as it finishes, with a
+// failed test's captured output indented underneath.
+type StandardVerbose struct{}
+
+// NewStandardVerbose builds the `go test -v`-style reporter.
+func NewStandardVerbose() *StandardVerbose { return &StandardVerbose{} }
+
+func (s *StandardVerbose) TestStarted(pkg, name string) {
+	fmt.Printf("=== RUN   %s.%s\n", pkg, name)
+}
+
+func (s *StandardVerbose) TestFinished(result TestResult) {
+	printStandardResult(result, true)
+}
+
+func (s *StandardVerbose) PackageFinished(pkg PackageResult) {}
+
+func (s *StandardVerbose) RunFinished(summary Summary) {
+	fmt.Println()
+	fmt.Println(RenderOverallSummary(summary))
+}
+
+// StandardQuiet is StandardVerbose without the "=== RUN"/"--- PASS" noise:
+// only failures (and their captured output) print as they happen, matching
+// plain `go test`'s own terseness.
+type StandardQuiet struct{}
+
+// NewStandardQuiet builds the failures-only `go test`-style reporter.
+func NewStandardQuiet() *StandardQuiet { return &StandardQuiet{} }
+
+func (s *StandardQuiet) TestStarted(pkg, name string) {}
+
+func (s *StandardQuiet) TestFinished(result TestResult) {
+	printStandardResult(result, false)
+}
+
+func (s *StandardQuiet) PackageFinished(pkg PackageResult) {}
+
+func (s *StandardQuiet) RunFinished(summary Summary) {
+	fmt.Println()
+	fmt.Println(RenderOverallSummary(summary))
+}
+
+// printStandardResult prints a `go test`-style "--- STATUS: pkg.Test
+// (dur)" line, with the failed case's output indented underneath. When
+// passing is false, only failures are printed at all.
+func printStandardResult(result TestResult, passing bool) {
+	if result.Status != StatusFail && !passing {
+		return
+	}
+
+	label := "PASS"
+	switch result.Status {
+	case StatusFail:
+		label = "FAIL"
+	case StatusSkip:
+		label = "SKIP"
+	case StatusFlaky:
+		label = "FLAKY"
+	}
+
+	fmt.Printf("--- %s: %s.%s (%v)\n", label, result.Package, result.Name, result.Duration)
+	if result.Status == StatusFail {
+		for _, line := range result.Output {
+			fmt.Println("    " + line)
+		}
+	}
+}
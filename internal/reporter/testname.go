@@ -0,0 +1,28 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Testname streams one line per test as it completes, e.g. "PASS
+// pkg.Test (12ms)". It's a quieter alternative to Dots when you want to see
+// which tests ran without Pretty's full block layout.
+type Testname struct{}
+
+// NewTestname builds the one-line-per-test reporter.
+func NewTestname() *Testname { return &Testname{} }
+
+func (t *Testname) TestStarted(pkg, name string) {}
+
+func (t *Testname) TestFinished(result TestResult) {
+	fmt.Printf("%s %s.%s (%v)\n",
+		strings.ToUpper(string(result.Status)), result.Package, result.Name, result.Duration)
+}
+
+func (t *Testname) PackageFinished(pkg PackageResult) {}
+
+func (t *Testname) RunFinished(summary Summary) {
+	fmt.Println()
+	fmt.Println(RenderOverallSummary(summary))
+}
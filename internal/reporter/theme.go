@@ -0,0 +1,44 @@
+package reporter
+
+import "charm.land/lipgloss/v2"
+
+// StatusRender is the glyph and style used to render one test/package
+// status. It's a plain DTO so this package stays independent of how
+// internal resolves its theme config.
+type StatusRender struct {
+	Glyph string
+	Style lipgloss.Style
+}
+
+// Theme is the full set of styles this package's Pretty/Dots renderers
+// use. It has no dependency on internal so it can be reused (or replaced)
+// without risking an import cycle with whatever resolves the config file.
+type Theme struct {
+	Pass    StatusRender
+	Fail    StatusRender
+	Skip    StatusRender
+	Flaky   StatusRender
+	Running StatusRender
+
+	Package  lipgloss.Style
+	TestName lipgloss.Style
+	Duration lipgloss.Style
+	Output   lipgloss.Style
+	Border   lipgloss.Border
+}
+
+// SetTheme installs t as the active theme for every Reporter implementation
+// in this package.
+func SetTheme(t Theme) {
+	passStyle, failStyle, skipStyle = t.Pass.Style, t.Fail.Style, t.Skip.Style
+	passGlyph, failGlyph, skipGlyph = t.Pass.Glyph, t.Fail.Glyph, t.Skip.Glyph
+	flakyStyle, flakyGlyph = t.Flaky.Style, t.Flaky.Glyph
+	runningStyle, runningGlyph = t.Running.Style, t.Running.Glyph
+
+	packageStyle = t.Package
+	testNameStyle = t.TestName
+	durationStyle = t.Duration
+	outputStyle = t.Output
+
+	pkgTableStyle = pkgTableStyle.Border(t.Border)
+}
@@ -0,0 +1,458 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/progress"
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	lg "charm.land/lipgloss/v2"
+)
+
+// RerunFunc re-runs the named tests in pkg and reports which of them passed.
+// internal.Execute wires this to the same helper --rerun-fails uses, so the
+// TUI's 'r' key reuses that machinery instead of duplicating it.
+type RerunFunc func(pkg string, names []string) (map[string]bool, error)
+
+// TUI is a live reporter that wraps the whole run in a tea.Program: rows
+// update in place as events arrive instead of only printing once a package
+// (or the whole run) finishes. internal.Execute selects it over the other
+// formats when --watch/--live is set (on by default when stdout is a
+// terminal) and --format was not passed explicitly.
+type TUI struct {
+	// Verbose seeds the model's initial "show failure output" state; set
+	// this before calling Start. The running TUI can still flip it with 'o'.
+	Verbose bool
+
+	prog     *tea.Program
+	finished chan struct{}
+	err      error
+}
+
+// NewTUI builds a TUI reporter. total is the estimated number of tests the
+// run will cover (from `go test -list`), used as the progress bar's
+// denominator; 0 means "unknown", and the bar falls back to a bare count.
+// rerun may be nil, in which case the 'r' key is a no-op.
+func NewTUI(total int, rerun RerunFunc) *TUI {
+	return &TUI{
+		prog:     tea.NewProgram(newTUIModel(total, rerun)),
+		finished: make(chan struct{}),
+	}
+}
+
+// Start launches the Bubble Tea program in the background. Callers must
+// call Start, with Verbose set, before streaming any events into the TUI.
+func (t *TUI) Start() {
+	t.prog.Send(setVerboseMsg(t.Verbose))
+	go func() {
+		_, t.err = t.prog.Run()
+		close(t.finished)
+	}()
+}
+
+// Kill tears down the program immediately, skipping the final render. Use
+// this to restore the terminal when the run itself failed before
+// RunFinished would otherwise have been called.
+func (t *TUI) Kill() {
+	t.prog.Kill()
+	<-t.finished
+}
+
+// Err returns any error the underlying tea.Program exited with.
+func (t *TUI) Err() error {
+	return t.err
+}
+
+func (t *TUI) TestStarted(pkg, name string) {
+	t.prog.Send(testStartedMsg{pkg: pkg, name: name})
+}
+
+func (t *TUI) TestFinished(result TestResult) {
+	t.prog.Send(testFinishedMsg{result: result})
+}
+
+func (t *TUI) PackageFinished(pkg PackageResult) {
+	t.prog.Send(packageFinishedMsg{pkg: pkg})
+}
+
+// RunFinished hands the final summary to the model, then blocks until the
+// user quits the TUI (pressing 'q'), so the terminal is restored and the
+// summary has actually been seen before Execute moves on.
+func (t *TUI) RunFinished(summary Summary) {
+	t.prog.Send(runFinishedMsg{summary: summary})
+	<-t.finished
+}
+
+type setVerboseMsg bool
+
+type testStartedMsg struct {
+	pkg, name string
+}
+
+type testFinishedMsg struct {
+	result TestResult
+}
+
+type packageFinishedMsg struct {
+	pkg PackageResult
+}
+
+type runFinishedMsg struct {
+	summary Summary
+}
+
+type rerunResultMsg struct {
+	passed map[string]bool
+	err    error
+}
+
+// runningTest tracks a test currently mid-flight, for the spinner rows.
+type runningTest struct {
+	pkg, name string
+	started   time.Time
+}
+
+// tuiModel is the Bubble Tea model driving TUI. Events from the pipeline
+// arrive as tea.Msg via TUI.prog.Send; keypresses drive the filter/verbose/
+// rerun state directly.
+type tuiModel struct {
+	rerun RerunFunc
+
+	total   int
+	passed  int
+	failed  int
+	skipped int
+	flaky   int
+
+	running map[string]runningTest
+
+	packages map[string]PackageResult
+	pkgOrder []string
+
+	filterFails bool
+	verbose     bool
+	searching   bool
+	query       string
+
+	rerunning bool
+	rerunErr  error
+
+	done    bool
+	summary Summary
+
+	spin spinner.Model
+	prog progress.Model
+}
+
+func newTUIModel(total int, rerun RerunFunc) tuiModel {
+	return tuiModel{
+		rerun:    rerun,
+		total:    total,
+		running:  make(map[string]runningTest),
+		packages: make(map[string]PackageResult),
+		spin:     spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(lg.NewStyle().Foreground(lg.Color("#8AFFFB")))),
+		prog:     progress.New(progress.WithDefaultBlend()),
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return m.spin.Tick
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case setVerboseMsg:
+		m.verbose = bool(msg)
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spin, cmd = m.spin.Update(msg)
+		return m, cmd
+
+	case tea.KeyPressMsg:
+		return m.handleKey(msg)
+
+	case testStartedMsg:
+		m.running[msg.pkg+"/"+msg.name] = runningTest{pkg: msg.pkg, name: msg.name, started: time.Now()}
+		return m, nil
+
+	case testFinishedMsg:
+		delete(m.running, msg.result.Package+"/"+msg.result.Name)
+		switch msg.result.Status {
+		case StatusPass:
+			m.passed++
+		case StatusFail:
+			m.failed++
+		case StatusSkip:
+			m.skipped++
+		}
+		m.appendResult(msg.result)
+		return m, nil
+
+	case packageFinishedMsg:
+		m.setPackage(msg.pkg)
+		return m, nil
+
+	case runFinishedMsg:
+		m.done = true
+		m.summary = msg.summary
+		return m, nil
+
+	case rerunResultMsg:
+		m.rerunning = false
+		m.rerunErr = msg.err
+		m.applyRerunResults(msg.passed)
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m *tuiModel) appendResult(result TestResult) {
+	pkg := m.packages[result.Package]
+	pkg.Name = result.Package
+	pkg.Tests = append(pkg.Tests, result)
+	m.setPackage(pkg)
+}
+
+func (m *tuiModel) setPackage(pkg PackageResult) {
+	if _, ok := m.packages[pkg.Name]; !ok {
+		m.pkgOrder = append(m.pkgOrder, pkg.Name)
+	}
+	m.packages[pkg.Name] = pkg
+}
+
+func (m tuiModel) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.searching {
+		switch msg.String() {
+		case "enter", "esc":
+			m.searching = false
+		case "backspace":
+			if len(m.query) > 0 {
+				m.query = m.query[:len(m.query)-1]
+			}
+		default:
+			if msg.Text != "" {
+				m.query += msg.Text
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "f":
+		m.filterFails = !m.filterFails
+	case "o":
+		m.verbose = !m.verbose
+	case "/":
+		m.searching = true
+	case "r":
+		failed := m.failedTests()
+		if m.rerunning || m.rerun == nil || len(failed) == 0 {
+			return m, nil
+		}
+		m.rerunning = true
+		return m, m.rerunCmd(failed)
+	}
+	return m, nil
+}
+
+// failedTests returns every genuinely failed TestResult across all
+// packages, in package/name order, excluding the synthetic "[build]"/
+// "[setup]" entries since there's nothing to re-run for those.
+func (m tuiModel) failedTests() []TestResult {
+	var out []TestResult
+	for _, pkgName := range m.pkgOrder {
+		for _, t := range m.packages[pkgName].Tests {
+			if t.Status == StatusFail && !strings.HasPrefix(t.Name, "[") {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// rerunCmd builds the tea.Cmd for 'r': it re-runs every currently failed
+// test, grouped by package, and reports back which of them passed.
+func (m tuiModel) rerunCmd(failed []TestResult) tea.Cmd {
+	rerun := m.rerun
+	return func() tea.Msg {
+		byPkg := make(map[string][]string)
+		for _, t := range failed {
+			byPkg[t.Package] = append(byPkg[t.Package], t.Name)
+		}
+
+		passed := make(map[string]bool)
+		var firstErr error
+		for pkg, names := range byPkg {
+			sort.Strings(names)
+			result, err := rerun(pkg, names)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			for name, ok := range result {
+				passed[pkg+"/"+name] = ok
+			}
+		}
+		return rerunResultMsg{passed: passed, err: firstErr}
+	}
+}
+
+// applyRerunResults folds a rerunResultMsg onto the model: any failed test
+// named in passed flips to StatusFlaky and is marked FlakyPassed, mirroring
+// how --rerun-fails treats a test that only passes on retry - counted
+// separately from a clean pass rather than folded back into it.
+func (m *tuiModel) applyRerunResults(passed map[string]bool) {
+	for _, pkgName := range m.pkgOrder {
+		pkg := m.packages[pkgName]
+		for i := range pkg.Tests {
+			key := pkg.Tests[i].Package + "/" + pkg.Tests[i].Name
+			if pkg.Tests[i].Status != StatusFail || !passed[key] {
+				continue
+			}
+			pkg.Tests[i].Status = StatusFlaky
+			pkg.Tests[i].FlakyPassed = true
+			pkg.Tests[i].Attempts++
+			pkg.Failed--
+			pkg.Flaky++
+			m.failed--
+			m.flaky++
+		}
+		if pkg.Failed == 0 && pkg.Status == StatusFail {
+			pkg.Status = StatusPass
+		}
+		m.packages[pkgName] = pkg
+	}
+}
+
+func (m tuiModel) View() tea.View {
+	sections := []string{m.renderHeader()}
+
+	if running := m.renderRunning(); running != "" {
+		sections = append(sections, running)
+	}
+	if completed := m.renderCompleted(); completed != "" {
+		sections = append(sections, completed)
+	}
+	if m.failed > 0 {
+		sections = append(sections, m.renderFailedPane())
+	}
+	sections = append(sections, m.renderFooter())
+
+	v := tea.NewView(strings.Join(sections, "\n\n"))
+	v.AltScreen = true
+	return v
+}
+
+func (m tuiModel) renderHeader() string {
+	completed := m.passed + m.failed + m.skipped
+
+	var bar string
+	if m.total > 0 {
+		bar = m.prog.ViewAs(float64(completed) / float64(m.total))
+	} else {
+		bar = fmt.Sprintf("%d run", completed)
+	}
+
+	counts := fmt.Sprintf("%s %d  %s %d  %s %d",
+		passStyle.Render(passGlyph), m.passed,
+		failStyle.Render(failGlyph), m.failed,
+		skipStyle.Render(skipGlyph), m.skipped,
+	)
+	if m.flaky > 0 {
+		counts += fmt.Sprintf("  %s %d", flakyStyle.Render(flakyGlyph), m.flaky)
+	}
+	if m.total > 0 {
+		counts = fmt.Sprintf("%d/%d - %s", completed, m.total, counts)
+	}
+
+	title := "prism --watch"
+	if m.done {
+		title = "prism --watch (finished - press q to exit)"
+	}
+
+	return strings.Join([]string{title, bar, counts}, "\n")
+}
+
+func (m tuiModel) renderRunning() string {
+	keys := make([]string, 0, len(m.running))
+	for key := range m.running {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, key := range keys {
+		rt := m.running[key]
+		if !m.matchesQuery(rt.name) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s/%s (%s)", m.spin.View(), rt.pkg, rt.name, time.Since(rt.started).Round(time.Second)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCompleted lists every non-failing finished test. It's suppressed by
+// the 'f' filter-to-failures toggle; failures themselves always show up in
+// the failed pane instead.
+func (m tuiModel) renderCompleted() string {
+	if m.filterFails {
+		return ""
+	}
+
+	var lines []string
+	for _, pkgName := range m.pkgOrder {
+		for _, t := range m.packages[pkgName].Tests {
+			if t.Status == StatusFail || !m.matchesQuery(t.Name) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s %s/%s", statusLabel(t.Status), t.Package, t.Name))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) renderFailedPane() string {
+	lines := []string{fmt.Sprintf("Failed (%d):", m.failed)}
+	for _, pkgName := range m.pkgOrder {
+		for _, t := range m.packages[pkgName].Tests {
+			if t.Status != StatusFail || !m.matchesQuery(t.Name) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s/%s", failStyle.Render(failGlyph), t.Package, t.Name))
+			if m.verbose {
+				for _, out := range t.Output {
+					lines = append(lines, outputStyle.Render("    "+out))
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) renderFooter() string {
+	if m.searching {
+		return lg.NewStyle().Faint(true).Render(fmt.Sprintf("search: %s_", m.query))
+	}
+	if m.rerunning {
+		return lg.NewStyle().Faint(true).Render("rerunning failed tests...")
+	}
+	if m.rerunErr != nil {
+		return errorFooterStyle.Render(fmt.Sprintf("rerun failed: %v", m.rerunErr))
+	}
+	return lg.NewStyle().Faint(true).Render("f: filter failures  o: toggle output  /: search  r: rerun failed  q: quit")
+}
+
+func (m tuiModel) matchesQuery(name string) bool {
+	if m.query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(m.query))
+}
+
+var errorFooterStyle = lg.NewStyle().Foreground(lg.Color("#FF8A80")).Bold(true)
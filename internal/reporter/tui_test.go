@@ -0,0 +1,56 @@
+package reporter
+
+import "testing"
+
+// TestApplyRerunResultsFlipsOnlyPassedTests confirms applyRerunResults only
+// flips the tests named in passed, marking them StatusFlaky/FlakyPassed and
+// keeping the model's failed/flaky counters and the owning package's counts
+// in sync.
+func TestApplyRerunResultsFlipsOnlyPassedTests(t *testing.T) {
+	m := newTUIModel(0, nil)
+	m.failed = 2
+	m.setPackage(PackageResult{
+		Name:   "pkg",
+		Failed: 2,
+		Status: StatusFail,
+		Tests: []TestResult{
+			{Package: "pkg", Name: "TestA", Status: StatusFail},
+			{Package: "pkg", Name: "TestB", Status: StatusFail},
+		},
+	})
+
+	m.applyRerunResults(map[string]bool{"pkg/TestA": true})
+
+	tests := m.packages["pkg"].Tests
+	if tests[0].Status != StatusFlaky || !tests[0].FlakyPassed {
+		t.Errorf("TestA = %+v, want flaky and flaky-passed", tests[0])
+	}
+	if tests[1].Status != StatusFail {
+		t.Errorf("TestB = %+v, want still failed", tests[1])
+	}
+	if m.flaky != 1 || m.failed != 1 {
+		t.Errorf("model counters = %d flaky, %d failed, want 1 and 1", m.flaky, m.failed)
+	}
+	if m.packages["pkg"].Status != StatusFail {
+		t.Errorf("package status = %q, want still fail since TestB is still failing", m.packages["pkg"].Status)
+	}
+}
+
+// TestFailedTestsExcludesSyntheticResults confirms the [build]/[setup]
+// placeholder results finishPackage synthesizes are excluded from rerun
+// candidates, since there's no real test to re-run for them.
+func TestFailedTestsExcludesSyntheticResults(t *testing.T) {
+	m := newTUIModel(0, nil)
+	m.setPackage(PackageResult{
+		Name: "pkg",
+		Tests: []TestResult{
+			{Package: "pkg", Name: "[build]", Status: StatusFail},
+			{Package: "pkg", Name: "TestReal", Status: StatusFail},
+		},
+	})
+
+	got := m.failedTests()
+	if len(got) != 1 || got[0].Name != "TestReal" {
+		t.Errorf("failedTests() = %+v, want only TestReal", got)
+	}
+}
@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rerunFailures implements --rerun-fails: it retries every genuinely failed
+// test (the synthetic "[build]"/"[setup]" entries are excluded, since there's
+// nothing to re-run) up to GlobalConfig.RerunFails times per package, tagging
+// any that eventually pass StatusFlaky instead of StatusPass so a run that
+// only went green on retry is never indistinguishable from one that didn't
+// need a retry at all. This only affects the final summary; per-package
+// output already streamed to the reporter during the first pass is left as
+// the original pass/fail counts it had at stream time, matching gotestsum's
+// own rerun-fails behavior. The final summary and --rerun-fails-report are
+// built from summary.Results; pkgAgg is updated afterward only so it agrees
+// with them.
+func rerunFailures(summary *TestSummary, pkgAgg map[string]*PackageResults) {
+	if GlobalConfig.RerunFails <= 0 {
+		return
+	}
+
+	byPkg := make(map[string]map[string]int) // package -> test name -> index into summary.Results
+	for i := range summary.Results {
+		r := &summary.Results[i]
+		if r.Status != StatusFail || strings.HasPrefix(r.Name, "[") {
+			continue
+		}
+		if byPkg[r.Package] == nil {
+			byPkg[r.Package] = make(map[string]int)
+		}
+		byPkg[r.Package][r.Name] = i
+	}
+
+	total := 0
+	for _, tests := range byPkg {
+		total += len(tests)
+	}
+	if total == 0 {
+		return
+	}
+	if GlobalConfig.RerunFailsMaxFailures > 0 && total > GlobalConfig.RerunFailsMaxFailures {
+		fmt.Fprintf(os.Stderr, "%s\n", errorStyle.Render(fmt.Sprintf(
+			"prism: %d failed tests exceeds --rerun-fails-max-failures=%d, skipping rerun",
+			total, GlobalConfig.RerunFailsMaxFailures,
+		)))
+		return
+	}
+
+	var flakyNames []string // "pkg.TestName", for --rerun-fails-report
+
+	for pkg, remaining := range byPkg {
+		for attempt := 1; attempt <= GlobalConfig.RerunFails && len(remaining) > 0; attempt++ {
+			names := make([]string, 0, len(remaining))
+			for name := range remaining {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			passed, err := rerunAttempt(pkg, names)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", errorStyle.Render(fmt.Sprintf(
+					"prism: rerun of %s failed: %v", pkg, err,
+				)))
+				break
+			}
+
+			for name := range passed {
+				i, ok := remaining[name]
+				if !ok {
+					continue
+				}
+				summary.Results[i].Status = StatusFlaky
+				summary.Results[i].FlakyPassed = true
+				summary.Results[i].Attempts = attempt + 1
+				summary.Failed--
+				summary.Flaky++
+				flakyNames = append(flakyNames, pkg+"."+name)
+				delete(remaining, name)
+			}
+		}
+		syncPackageAfterRerun(pkg, pkgAgg, summary)
+	}
+
+	if err := writeRerunFailsReport(flakyNames); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", errorStyle.Render(fmt.Sprintf(
+			"prism: failed to write --rerun-fails-report: %v", err,
+		)))
+	}
+}
+
+// syncPackageAfterRerun refreshes pkg's Tests/Failed/Flaky/Status in pkgAgg
+// to match summary.Results after rerunFailures has flipped some of that
+// package's tests to StatusFlaky. It's a separate pass rather than live
+// per-test updates since the package's PackageResult may already have
+// streamed to the reporter (with its original fail count) before any rerun
+// ran.
+func syncPackageAfterRerun(pkgName string, pkgAgg map[string]*PackageResults, summary *TestSummary) {
+	pkg, ok := pkgAgg[pkgName]
+	if !ok {
+		return
+	}
+
+	byName := make(map[string]TestResult, len(pkg.Tests))
+	for _, r := range summary.Results {
+		if r.Package == pkgName {
+			byName[r.Name] = r
+		}
+	}
+
+	pkg.Failed, pkg.Flaky = 0, 0
+	for i, t := range pkg.Tests {
+		updated, ok := byName[t.Name]
+		if !ok {
+			continue
+		}
+		pkg.Tests[i] = updated
+		switch updated.Status {
+		case StatusFail:
+			pkg.Failed++
+		case StatusFlaky:
+			pkg.Flaky++
+		}
+	}
+	if pkg.Failed == 0 && pkg.Status == StatusFail {
+		pkg.Status = StatusPass
+	}
+}
+
+// writeRerunFailsReport writes names (one "pkg.TestName" per line) to
+// GlobalConfig.RerunFailsReport. A blank path is a no-op, matching how
+// --junitfile treats an unset path.
+func writeRerunFailsReport(names []string) error {
+	path := GlobalConfig.RerunFailsReport
+	if path == "" {
+		return nil
+	}
+
+	sort.Strings(names)
+	content := strings.Join(names, "\n")
+	if len(names) > 0 {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// rerunAttempt re-runs each of names in pkg and reports which of them
+// passed. Go's -run flag splits its whole pattern on unescaped "/" and
+// matches each segment against the corresponding depth of a test's name
+// (root test, then each subtest) - it does not treat "/" inside one
+// alternative as part of that alternative. That means a single combined
+// `^(A|B)$` pattern across names of different depths (e.g. "TestFailA" and
+// "TestSubtests/failing_case") produces garbage regex fragments instead of
+// selecting both, so each name is run in its own `go test` invocation with
+// a pattern built from runPatternFor instead.
+func rerunAttempt(pkg string, names []string) (map[string]bool, error) {
+	passed := make(map[string]bool)
+	for _, name := range names {
+		ok, err := rerunOne(pkg, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			passed[name] = true
+		}
+	}
+	return passed, nil
+}
+
+// rerunOne runs `go test -json -run <pattern> -count=1 pkg`, where pattern
+// selects exactly name, and reports whether name passed.
+func rerunOne(pkg, name string) (bool, error) {
+	cmd := exec.CommandContext(context.Background(), "go", "test", "-json", "-run", runPatternFor(name), "-count=1", pkg)
+
+	out, runErr := cmd.Output()
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); !ok {
+			return false, runErr
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		var event TestEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if event.Test == name && Status(event.Action) == StatusPass {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// runPatternFor builds a -run pattern that selects exactly name, anchoring
+// each "/"-separated depth on its own since that's how -run itself splits
+// the pattern: "TestNestedSubtests/group1/fail" becomes
+// "^TestNestedSubtests$/^group1$/^fail$".
+func runPatternFor(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunPatternFor(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"TestTop", "^TestTop$"},
+		{"TestSubtests/failing_case", "^TestSubtests$/^failing_case$"},
+		{"TestNestedSubtests/group1/fail", "^TestNestedSubtests$/^group1$/^fail$"},
+		{"TestWeird[case]", `^TestWeird\[case\]$`},
+	}
+
+	for _, c := range cases {
+		if got := runPatternFor(c.name); got != c.want {
+			t.Errorf("runPatternFor(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+// TestRerunAttemptMatchesSubtestAndTopLevelNames reproduces the bug where a
+// single flat `^(A|B)$` alternation across a mix of top-level and subtest
+// names broke go test's own "/"-delimited -run splitting: combining
+// "TestTop" with "TestWithSubtests/child" in one pattern produced an
+// uncompilable regex, and the resulting *exec.ExitError was swallowed as a
+// normal nonzero exit, silently returning an empty passed map. Rerunning
+// each name in its own invocation must report both as passed.
+func TestRerunAttemptMatchesSubtestAndTopLevelNames(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "go.mod"), "module rerunfixture\n\ngo 1.21\n")
+	writeFile(t, filepath.Join(dir, "fixture_test.go"), `package rerunfixture
+
+import "testing"
+
+func TestTop(t *testing.T) {}
+
+func TestWithSubtests(t *testing.T) {
+	t.Run("child", func(t *testing.T) {})
+	t.Run("other", func(t *testing.T) { t.Fatal("always fails") })
+}
+`)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to enter fixture dir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	passed, err := rerunAttempt("rerunfixture", []string{"TestTop", "TestWithSubtests/child"})
+	if err != nil {
+		t.Fatalf("rerunAttempt returned an unexpected error: %v", err)
+	}
+
+	want := map[string]bool{"TestTop": true, "TestWithSubtests/child": true}
+	if len(passed) != len(want) {
+		t.Fatalf("rerunAttempt() = %v, want %v", passed, want)
+	}
+	for name := range want {
+		if !passed[name] {
+			t.Errorf("expected %q to be reported as passed, got %v", name, passed)
+		}
+	}
+}
@@ -1,12 +1,13 @@
 package internal
 
-import "github.com/charmbracelet/lipgloss/v2"
+import "charm.land/lipgloss/v2"
 
 // --- Lipgloss Styles (Less harsh colors) ---
 var (
-	passStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#8AFF8A")).Bold(true) // Light Green
-	failStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF8A80")).Bold(true) // Light Red/Coral
-	skipStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFAA")).Bold(true) // Pale Yellow
+	passStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#8AFF8A")).Bold(true) // Light Green
+	failStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF8A80")).Bold(true) // Light Red/Coral
+	skipStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFAA")).Bold(true) // Pale Yellow
+	flakyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Bold(true) // Orange
 
 	packageStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#8AFFFB")).Bold(true) // Light Aqua
 	testNameStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E0E0E0"))            // Off-white
@@ -19,6 +20,17 @@ var (
 	packageSeparatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#444444")).Faint(true) // Dark Gray
 )
 
+// Status glyphs, overridable via a theme config file.
+var (
+	passGlyph    = "✓"
+	failGlyph    = "✗"
+	skipGlyph    = "⊝"
+	flakyGlyph   = "↻"
+	runningGlyph = "◌"
+
+	runningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#B0B0B0"))
+)
+
 // --- Global Application Styles ---
 var (
 	// AppOverallOutputStyle is the top-level style that wraps all the display output.
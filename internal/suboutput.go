@@ -0,0 +1,85 @@
+package internal
+
+import "strings"
+
+// splitTestName splits a go test -json test name into its root test and
+// subtest path, e.g. "TestNestedSubtests/group1/fail" becomes
+// ("TestNestedSubtests", "group1/fail"). A bare top-level test has an empty
+// subtest.
+func splitTestName(name string) (root, sub string) {
+	if i := strings.Index(name, "/"); i != -1 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// subtestTracker buffers per-test output, keyed first by root test name and
+// then by subtest name, and tracks which subtest is currently running under
+// each root. `go test -json` sometimes attributes output emitted while a
+// subtest runs to the root test's bare name instead of the subtest's own
+// full name; routing output events through here lets the caller recover it
+// instead of losing it, or attaching it to the wrong row.
+type subtestTracker struct {
+	output map[string]map[string]map[string][]string // pkg -> root -> sub -> lines
+	active map[string]map[string]string              // pkg -> root -> currently running sub
+}
+
+func newSubtestTracker() *subtestTracker {
+	return &subtestTracker{
+		output: make(map[string]map[string]map[string][]string),
+		active: make(map[string]map[string]string),
+	}
+}
+
+// started marks sub as the subtest currently running under root, so output
+// events that arrive attributed only to root get routed to it.
+func (t *subtestTracker) started(pkg, root, sub string) {
+	if sub == "" {
+		return
+	}
+	if t.active[pkg] == nil {
+		t.active[pkg] = make(map[string]string)
+	}
+	t.active[pkg][root] = sub
+}
+
+// finished clears sub as root's active subtest once it reaches a terminal
+// status, so later root-attributed output isn't misrouted to it.
+func (t *subtestTracker) finished(pkg, root, sub string) {
+	if sub != "" && t.active[pkg][root] == sub {
+		delete(t.active[pkg], root)
+	}
+}
+
+// record buffers line for the test named name under pkg. If name is a bare
+// root (no subtest path) and a subtest is currently active under it, the
+// line is routed to that subtest instead.
+func (t *subtestTracker) record(pkg, name, line string) {
+	root, sub := splitTestName(name)
+	if sub == "" {
+		if active, ok := t.active[pkg][root]; ok {
+			sub = active
+		}
+	}
+
+	if t.output[pkg] == nil {
+		t.output[pkg] = make(map[string]map[string][]string)
+	}
+	if t.output[pkg][root] == nil {
+		t.output[pkg][root] = make(map[string][]string)
+	}
+	t.output[pkg][root][sub] = append(t.output[pkg][root][sub], line)
+}
+
+// flush returns and clears the buffered lines for the test named name under
+// pkg.
+func (t *subtestTracker) flush(pkg, name string) []string {
+	root, sub := splitTestName(name)
+	byRoot := t.output[pkg]
+	if byRoot == nil || byRoot[root] == nil {
+		return nil
+	}
+	lines := byRoot[root][sub]
+	delete(byRoot[root], sub)
+	return lines
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.dalton.dog/prism/internal/reporter"
+)
+
+// TestSubtestTrackerRoutesRootOutputToActiveSubtest reproduces the
+// test2json ordering quirk chunk1-2 is about: an output event arrives
+// attributed to the bare root test name while a subtest is running. It
+// should still end up flushed into that subtest's own output, not the
+// root's.
+func TestSubtestTrackerRoutesRootOutputToActiveSubtest(t *testing.T) {
+	tracker := newSubtestTracker()
+
+	tracker.started("pkg", "TestSubtests", "failing_case")
+	tracker.record("pkg", "TestSubtests", "misattributed to the root")
+	tracker.record("pkg", "TestSubtests/failing_case", "attributed directly")
+	tracker.finished("pkg", "TestSubtests", "failing_case")
+
+	got := tracker.flush("pkg", "TestSubtests/failing_case")
+	want := []string{"misattributed to the root", "attributed directly"}
+
+	if len(got) != len(want) {
+		t.Fatalf("flush() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("flush()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if root := tracker.flush("pkg", "TestSubtests"); len(root) != 0 {
+		t.Errorf("expected no leftover root output, got %v", root)
+	}
+}
+
+// TestRunTestsAttributesPkg3SubtestOutputToTheRightRow runs the real
+// TestSubtests/TestNestedSubtests fixtures in pkg3 through runTests
+// end-to-end, confirming the synthetic tracking in
+// TestSubtestTrackerRoutesRootOutputToActiveSubtest actually produces the
+// right per-row output when test2json events come from a live `go test`
+// process instead of being fed in by hand.
+func TestRunTestsAttributesPkg3SubtestOutputToTheRightRow(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(filepath.Dir(cwd)); err != nil {
+		t.Fatalf("failed to enter repo root: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	summary, err := runTests([]string{"test", "-json", "./pkg3"}, reporter.NewSilent())
+	if err != nil {
+		t.Fatalf("runTests returned an unexpected error: %v", err)
+	}
+
+	find := func(name string) *TestResult {
+		for i := range summary.Results {
+			if summary.Results[i].Name == name {
+				return &summary.Results[i]
+			}
+		}
+		return nil
+	}
+
+	failingCase := find("TestSubtests/failing_case")
+	if failingCase == nil {
+		t.Fatalf("expected a TestSubtests/failing_case result, got: %+v", summary.Results)
+	}
+	if !containsSubstring(failingCase.Output, "Testing input: 4") {
+		t.Errorf("expected failing_case's own output in its row, got: %v", failingCase.Output)
+	}
+
+	nestedFail := find("TestNestedSubtests/group1/fail")
+	if nestedFail == nil {
+		t.Fatalf("expected a TestNestedSubtests/group1/fail result, got: %+v", summary.Results)
+	}
+	if !containsSubstring(nestedFail.Output, "Nested test that fails") {
+		t.Errorf("expected group1/fail's own output in its row, got: %v", nestedFail.Output)
+	}
+
+	for _, name := range []string{"TestSubtests", "TestNestedSubtests"} {
+		root := find(name)
+		if root == nil {
+			continue
+		}
+		if containsSubstring(root.Output, "Testing input: 4") || containsSubstring(root.Output, "Nested test that fails") {
+			t.Errorf("expected %s not to carry its subtests' output, got: %v", name, root.Output)
+		}
+	}
+}
+
+func TestSplitTestName(t *testing.T) {
+	cases := []struct {
+		name     string
+		wantRoot string
+		wantSub  string
+	}{
+		{"TestSubtests", "TestSubtests", ""},
+		{"TestSubtests/failing_case", "TestSubtests", "failing_case"},
+		{"TestNestedSubtests/group1/fail", "TestNestedSubtests", "group1/fail"},
+	}
+
+	for _, c := range cases {
+		root, sub := splitTestName(c.name)
+		if root != c.wantRoot || sub != c.wantSub {
+			t.Errorf("splitTestName(%q) = (%q, %q), want (%q, %q)", c.name, root, sub, c.wantRoot, c.wantSub)
+		}
+	}
+}
@@ -2,29 +2,61 @@ package internal
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
+
+	"go.dalton.dog/prism/internal/reporter"
+)
+
+var (
+	// buildPkgHeaderRe matches the "# <pkg>" line `go test` prints ahead of
+	// a package's own compiler output.
+	buildPkgHeaderRe = regexp.MustCompile(`^# (\S+)`)
+	// buildFailRe matches the plain-text "FAIL <pkg> [build failed]" /
+	// "[setup failed]" line `go test -json` falls back to emitting instead
+	// of a JSON event when a package never got far enough to run a test.
+	buildFailRe = regexp.MustCompile(`^FAIL\s+(\S+)\s+\[(?:build|setup) failed\]`)
 )
 
 func Execute(args []string) {
-	cmdArgs := []string{"test", "-json"}
+	configureLogger(os.Stderr)
+
 	userArgs := os.Args[1:]
+	cmdArgs := append([]string{"test", "-json"}, testTargetArgs(userArgs)...)
 
-	if len(userArgs) == 0 {
-		cmdArgs = append(cmdArgs, "./...")
+	var rep reporter.Reporter
+	var tui *reporter.TUI
+	if GlobalConfig.Watch && !GlobalConfig.FormatExplicit {
+		tui = reporter.NewTUI(estimateTestTotal(userArgs), rerunAttempt)
+		tui.Verbose = GlobalConfig.Verbose
+		tui.Start()
+		rep = tui
 	} else {
-		cmdArgs = append(cmdArgs, userArgs...)
+		var err error
+		rep, err = reporter.New(GlobalConfig.Format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", errorStyle.Render(fmt.Sprintf("Error: %v", err)))
+			os.Exit(1)
+		}
+		if pretty, ok := rep.(*reporter.Pretty); ok {
+			pretty.Verbose = GlobalConfig.Verbose
+		}
 	}
 
-	summary, err := runTests(cmdArgs)
+	summary, err := runTests(cmdArgs, rep)
 	if err != nil {
+		if tui != nil {
+			tui.Kill()
+		}
 		fmt.Fprintf(
 			os.Stderr,
 			"%s\n",
@@ -32,10 +64,19 @@ func Execute(args []string) {
 		)
 		os.Exit(1)
 	}
+	if tui != nil {
+		if tuiErr := tui.Err(); tuiErr != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", errorStyle.Render(fmt.Sprintf("TUI error: %v", tuiErr)))
+		}
+	}
 
-	// Capture all display output as a single string and wrap it
-	fullOutput := displayResults(summary)
-	fmt.Println(AppOverallOutputStyle.Render(fullOutput))
+	if err := writeJUnitReport(summary); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"%s\n",
+			errorStyle.Render(fmt.Sprintf("Error writing JUnit report: %v", err)),
+		)
+	}
 
 	if summary.Failed > 0 {
 		os.Exit(1)
@@ -43,7 +84,9 @@ func Execute(args []string) {
 	os.Exit(0)
 }
 
-func runTests(args []string) (*TestSummary, error) {
+// runTests runs `go test -json <args>`, streaming each event into rep as it
+// arrives, and returns the fully aggregated summary once the command exits.
+func runTests(args []string, rep reporter.Reporter) (*TestSummary, error) {
 	cmd := exec.CommandContext(context.Background(), "go", args...)
 
 	stdout, err := cmd.StdoutPipe()
@@ -60,52 +103,61 @@ func runTests(args []string) (*TestSummary, error) {
 	}
 
 	summary := &TestSummary{
-		Results: make([]TestResult, 0),
+		Results:       make([]TestResult, 0),
+		PackageEvents: make(map[string][]string),
+		PackageFinal:  make(map[string]Status),
 	}
 	testMap := make(map[string]*TestResult)
+	pkgAgg := make(map[string]*PackageResults)
+	buildScan := &buildScanState{}
+	subtests := newSubtestTracker()
+
+	// A live TUI owns the terminal via its alt screen, so raw stderr lines
+	// get buffered into PackageEvents like usual but aren't also echoed
+	// directly - that would tear up the rendered view. They still surface
+	// to the user via the synthetic [build]/[setup] result's output.
+	_, isTUI := rep.(*reporter.TUI)
 
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
 		scanner := bufio.NewScanner(stdout)
+		lineNum := 0
 		for scanner.Scan() {
+			lineNum++
 			var event TestEvent
 			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
-				fmt.Fprintf(
-					os.Stderr,
-					"%s\n",
-					errorStyle.Render(fmt.Sprintf(
-						"Warning: Failed to unmarshal JSON event: %v (line: %s)",
-						err,
-						scanner.Text(),
-					)),
+				if handleRawOutputLine(scanner.Text(), buildScan, summary) {
+					continue
+				}
+				Logger.Warn("failed to unmarshal test2json event",
+					"error", err,
+					"line_number", lineNum,
+					"line", scanner.Text(),
 				)
 				continue
 			}
-			processEvent(&event, testMap, summary)
+			processEvent(&event, testMap, pkgAgg, summary, rep, subtests)
 		}
 		if err := scanner.Err(); err != nil && err != io.EOF {
-			fmt.Fprintf(
-				os.Stderr,
-				"%s\n",
-				errorStyle.Render(fmt.Sprintf("Error reading stdout: %v", err)),
-			)
+			Logger.Error("error reading go test stdout", "error", err)
 		}
 	}()
 
 	go func() {
+		defer wg.Done()
 		scanner := bufio.NewScanner(stderr)
 		for scanner.Scan() {
-			fmt.Fprintf(os.Stderr, "%s\n", scanner.Text())
+			line := scanner.Text()
+			if !isTUI {
+				fmt.Fprintf(os.Stderr, "%s\n", line)
+			}
+			handleRawOutputLine(line, buildScan, summary)
 		}
 		if err := scanner.Err(); err != nil && err != io.EOF {
-			fmt.Fprintf(
-				os.Stderr,
-				"%s\n",
-				errorStyle.Render(fmt.Sprintf("Error reading stderr: %v", err)),
-			)
+			Logger.Error("error reading go test stderr", "error", err)
 		}
 	}()
 
@@ -113,15 +165,29 @@ func runTests(args []string) (*TestSummary, error) {
 
 	wg.Wait()
 
-	for _, result := range testMap {
-		summary.Results = append(summary.Results, *result)
+	// Packages that only ever produced plain-text build output (no JSON
+	// event ever named them) never ran through finishPackage live, since
+	// stdout and stderr are read by separate goroutines with no ordering
+	// guarantee between them; resolve those now that both streams are
+	// fully drained.
+	for pkg, final := range summary.PackageFinal {
+		if _, ok := pkgAgg[pkg]; !ok {
+			finishPackage(pkg, final, pkgAgg, summary, rep)
+		}
 	}
 
+	rerunFailures(summary, pkgAgg)
+
+	rep.RunFinished(toReporterSummary(summary))
+
 	if cmdErr != nil {
 		if exitErr, ok := cmdErr.(*exec.ExitError); ok {
 			if exitErr.ExitCode() == 1 {
 				return summary, nil
 			}
+			Logger.Error("go test exited with unexpected status",
+				"exit_code", exitErr.ExitCode(),
+			)
 			return nil, fmt.Errorf(
 				"command exited with non-zero status %d: %w",
 				exitErr.ExitCode(),
@@ -134,8 +200,134 @@ func runTests(args []string) (*TestSummary, error) {
 	return summary, nil
 }
 
-func processEvent(event *TestEvent, testMap map[string]*TestResult, summary *TestSummary) {
+// testTargetArgs returns userArgs, defaulting to "./..." when the user gave
+// no package/test arguments of their own.
+func testTargetArgs(userArgs []string) []string {
+	if len(userArgs) == 0 {
+		return []string{"./..."}
+	}
+	return userArgs
+}
+
+// estimateTestTotal runs `go test -list` over the same targets Execute is
+// about to test, for the live TUI's progress bar denominator. It's
+// best-effort: any failure just means the bar renders without a known total.
+func estimateTestTotal(userArgs []string) int {
+	listArgs := append([]string{"test", "-list", ".*"}, testTargetArgs(userArgs)...)
+	out, err := exec.CommandContext(context.Background(), "go", listArgs...).Output()
+	if err != nil {
+		return 0
+	}
+
+	total := 0
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Test") || strings.HasPrefix(line, "Example") || strings.HasPrefix(line, "Benchmark") {
+			total++
+		}
+	}
+	return total
+}
+
+// buildScanState tracks the package named by the most recent "# pkg" header
+// across both the stdout and stderr scanning goroutines. `go test -json`
+// splits a package's build/setup failure across both streams — the header
+// and compiler output go to stderr, the "FAIL ... [setup failed]" line goes
+// to stdout — with no ordering guarantee between the two, so this state
+// must be shared rather than kept per-goroutine.
+type buildScanState struct {
+	mu  sync.Mutex
+	pkg string
+}
+
+// handleRawOutputLine processes a stdout or stderr line that isn't valid
+// test2json. `go test -json` falls back to printing plain text rather than
+// a JSON event when a package fails to compile or panics before any test can
+// run — a longstanding test2json limitation gotestsum works around the same
+// way. It reports whether the line was recognized as build output, so
+// callers can still warn about truly malformed JSON. Recognizing a package's
+// terminal failure here only records it in summary.PackageFinal; runTests
+// calls finishPackage for it once both streams have been fully drained, so
+// that it isn't resolved before the other stream has appended its half of
+// the output.
+func handleRawOutputLine(line string, scan *buildScanState, summary *TestSummary) bool {
+	scan.mu.Lock()
+	if m := buildPkgHeaderRe.FindStringSubmatch(line); m != nil {
+		scan.pkg = m[1]
+	}
+	currentPkg := scan.pkg
+	scan.mu.Unlock()
+
+	handled := currentPkg != ""
+	if handled {
+		summary.Lock()
+		summary.PackageEvents[currentPkg] = append(summary.PackageEvents[currentPkg], line)
+		summary.Unlock()
+	}
+
+	if m := buildFailRe.FindStringSubmatch(line); m != nil {
+		pkg := m[1]
+		summary.Lock()
+		summary.PackageFinal[pkg] = StatusFail
+		summary.Unlock()
+		handled = true
+	}
+
+	return handled
+}
+
+// packageFromImportPath recovers a package's import path from the
+// ImportPath a "build-output"/"build-fail" event carries, which names the
+// synthesized test binary rather than the package itself (e.g.
+// "go.dalton.dog/prism/internal.test").
+func packageFromImportPath(importPath string) string {
+	return strings.TrimSuffix(importPath, ".test")
+}
+
+// processEvent updates testMap/pkgAgg/summary from a single `go test -json`
+// event and, for events that complete a test or package, streams the
+// corresponding notification into rep. subtests buffers per-test output so
+// it can be correctly attributed to a subtest even if test2json's "output"
+// events name only its parent.
+func processEvent(
+	event *TestEvent,
+	testMap map[string]*TestResult,
+	pkgAgg map[string]*PackageResults,
+	summary *TestSummary,
+	rep reporter.Reporter,
+	subtests *subtestTracker,
+) {
+	action := Status(event.Action)
+
+	// Package-scoped events (no Test name) carry compile errors, TestMain
+	// panics, and the package's own final pass/fail/skip action. These used
+	// to be dropped entirely; buffer them so finishPackage can surface
+	// anything that never attached to an individual test.
 	if event.Test == "" {
+		summary.Lock()
+		defer summary.Unlock()
+
+		// A build/setup failure's own compiler diagnostic is keyed by
+		// ImportPath instead of Package (as "build-output"/"build-fail"
+		// actions) - the package's regular pass/fail/skip action still
+		// arrives separately with Package set, so fall back to deriving it
+		// from ImportPath only for these two.
+		pkg := event.Package
+		if pkg == "" {
+			pkg = packageFromImportPath(event.ImportPath)
+		}
+
+		switch action {
+		case StatusOutput, StatusBuildOutput, StatusBuildFail:
+			line := strings.TrimSpace(event.Output)
+			if line != "" {
+				summary.PackageEvents[pkg] = append(summary.PackageEvents[pkg], line)
+			}
+		case StatusPass, StatusFail, StatusSkip:
+			summary.PackageFinal[pkg] = action
+			finishPackage(pkg, action, pkgAgg, summary, rep)
+		}
 		return
 	}
 
@@ -147,27 +339,36 @@ func processEvent(event *TestEvent, testMap map[string]*TestResult, summary *Tes
 	result, exists := testMap[key]
 	if !exists {
 		result = &TestResult{
-			Name:    event.Test,
-			Package: event.Package,
-			Status:  StatusRunning,
-			Output:  make([]string, 0),
+			Name:     event.Test,
+			Package:  event.Package,
+			Status:   StatusRunning,
+			Output:   make([]string, 0),
+			Attempts: 1,
 		}
 		testMap[key] = result
 		summary.Total++
 	}
 
-	switch event.Action {
+	root, sub := splitTestName(event.Test)
+
+	switch action {
+	case StatusRun:
+		subtests.started(event.Package, root, sub)
+		rep.TestStarted(event.Package, event.Test)
+
 	case StatusOutput:
 		output := strings.TrimSpace(event.Output)
 		if output != "" {
-			result.Output = append(result.Output, output)
+			subtests.record(event.Package, event.Test, output)
 		}
 
 	case StatusPass, StatusFail, StatusSkip:
-		result.Status = event.Action
+		subtests.finished(event.Package, root, sub)
+		result.Status = action
 		result.Duration = time.Duration(event.Elapsed * float64(time.Second))
+		result.Output = append(result.Output, subtests.flush(event.Package, event.Test)...)
 
-		switch event.Action {
+		switch action {
 		case StatusPass:
 			summary.Passed++
 		case StatusFail:
@@ -175,5 +376,10 @@ func processEvent(event *TestEvent, testMap map[string]*TestResult, summary *Tes
 		case StatusSkip:
 			summary.Skipped++
 		}
+
+		finished := *result
+		summary.Results = append(summary.Results, finished)
+		rep.TestFinished(toReporterResult(finished))
+		appendToPackage(pkgAgg, finished)
 	}
 }
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/prism/internal/reporter"
+)
+
+// TestRunTestsSurfacesBuildFailure feeds a package that fails to compile
+// through runTests and asserts the compiler's own error text survives into
+// both the buffered package output and the synthetic [build] TestResult
+// that finishPackage produces for it, then confirms the same text reaches
+// the JUnit report.
+func TestRunTestsSurfacesBuildFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "go.mod"), "module broken\n\ngo 1.21\n")
+	writeFile(t, filepath.Join(dir, "broken_test.go"), `package broken
+
+func TestBroken(t *testing.T) {
+	this does not parse
+}
+`)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to enter broken package dir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	summary, err := runTests([]string{"test", "-json", "./..."}, reporter.NewSilent())
+	if err != nil {
+		t.Fatalf("runTests returned an unexpected error: %v", err)
+	}
+
+	var synthetic *TestResult
+	for i := range summary.Results {
+		if summary.Results[i].Name == "[build]" {
+			synthetic = &summary.Results[i]
+			break
+		}
+	}
+	if synthetic == nil {
+		t.Fatalf("expected a synthetic [build] result, got: %+v", summary.Results)
+	}
+	if !containsSubstring(synthetic.Output, "syntax error") && !containsSubstring(synthetic.Output, "expected") {
+		t.Fatalf("expected compile error text in [build] output, got: %v", synthetic.Output)
+	}
+
+	reportPath := filepath.Join(dir, "report.xml")
+	GlobalConfig.JunitFile = reportPath
+	defer func() { GlobalConfig.JunitFile = "" }()
+
+	if err := writeJUnitReport(summary); err != nil {
+		t.Fatalf("writeJUnitReport returned an error: %v", err)
+	}
+
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("failed to read junit report: %v", err)
+	}
+	if !strings.Contains(string(report), "[build]") {
+		t.Errorf("expected junit report to contain the [build] testcase, got: %s", report)
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func containsSubstring(lines []string, substr string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"charm.land/lipgloss/v2"
+	"go.dalton.dog/prism/internal/reporter"
+	"gopkg.in/yaml.v3"
+)
+
+// StatusStyleConfig is the user-overridable rendering for a single status:
+// its glyph and lipgloss attributes. Fields are pointers so "unset" can be
+// told apart from "explicitly false/empty" when merging onto defaults.
+type StatusStyleConfig struct {
+	Glyph  *string `yaml:"glyph"`
+	Color  *string `yaml:"color"`
+	Bold   *bool   `yaml:"bold"`
+	Italic *bool   `yaml:"italic"`
+	Faint  *bool   `yaml:"faint"`
+}
+
+// TextStyleConfig is the user-overridable rendering for non-status text:
+// package names, test names, durations, and captured output.
+type TextStyleConfig struct {
+	Color  *string `yaml:"color"`
+	Bold   *bool   `yaml:"bold"`
+	Italic *bool   `yaml:"italic"`
+	Faint  *bool   `yaml:"faint"`
+}
+
+// ThemeConfig is the shape of ~/.config/prism/config.yaml. Every field is
+// optional; whatever's left unset falls back to the built-in defaults
+// already in styles.go.
+type ThemeConfig struct {
+	Pass    *StatusStyleConfig `yaml:"pass"`
+	Fail    *StatusStyleConfig `yaml:"fail"`
+	Skip    *StatusStyleConfig `yaml:"skip"`
+	Flaky   *StatusStyleConfig `yaml:"flaky"`
+	Running *StatusStyleConfig `yaml:"running"`
+
+	Package  *TextStyleConfig `yaml:"package"`
+	TestName *TextStyleConfig `yaml:"test_name"`
+	Duration *TextStyleConfig `yaml:"duration"`
+	Output   *TextStyleConfig `yaml:"output"`
+
+	// Border selects the package/summary table border: "rounded" (the
+	// default), "normal", or "hidden".
+	Border string `yaml:"border"`
+}
+
+// defaultConfigPath returns ~/.config/prism/config.yaml, or "" if the home
+// directory can't be resolved.
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "prism", "config.yaml")
+}
+
+// LoadThemeConfig reads and parses a theme config file. An empty path
+// falls back to defaultConfigPath(). A missing file at either location
+// isn't an error — it just means "use the built-in defaults".
+func LoadThemeConfig(path string) (*ThemeConfig, error) {
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read theme config %s: %w", path, err)
+	}
+
+	var cfg ThemeConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse theme config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// noColorRequested reports whether NO_COLOR or PRISM_NO_COLOR asks for
+// plain, uncolored output. See https://no-color.org.
+func noColorRequested() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("PRISM_NO_COLOR") != ""
+}
+
+// applyStatusOverrides resolves cfg onto (glyph, style), returning the
+// built-in default unchanged if cfg is nil. When plain is true, color and
+// text attributes are dropped regardless of cfg, leaving only the glyph.
+func applyStatusOverrides(glyph string, style lipgloss.Style, cfg *StatusStyleConfig, plain bool) (string, lipgloss.Style) {
+	if cfg != nil && cfg.Glyph != nil {
+		glyph = *cfg.Glyph
+	}
+	if plain {
+		return glyph, lipgloss.NewStyle()
+	}
+	if cfg == nil {
+		return glyph, style
+	}
+	if cfg.Color != nil {
+		style = style.Foreground(lipgloss.Color(*cfg.Color))
+	}
+	if cfg.Bold != nil {
+		style = style.Bold(*cfg.Bold)
+	}
+	if cfg.Italic != nil {
+		style = style.Italic(*cfg.Italic)
+	}
+	if cfg.Faint != nil {
+		style = style.Faint(*cfg.Faint)
+	}
+	return glyph, style
+}
+
+// applyTextOverrides resolves cfg onto style the same way
+// applyStatusOverrides does, minus the glyph.
+func applyTextOverrides(style lipgloss.Style, cfg *TextStyleConfig, plain bool) lipgloss.Style {
+	if plain {
+		return lipgloss.NewStyle()
+	}
+	if cfg == nil {
+		return style
+	}
+	if cfg.Color != nil {
+		style = style.Foreground(lipgloss.Color(*cfg.Color))
+	}
+	if cfg.Bold != nil {
+		style = style.Bold(*cfg.Bold)
+	}
+	if cfg.Italic != nil {
+		style = style.Italic(*cfg.Italic)
+	}
+	if cfg.Faint != nil {
+		style = style.Faint(*cfg.Faint)
+	}
+	return style
+}
+
+func borderFromName(name string) lipgloss.Border {
+	switch name {
+	case "normal":
+		return lipgloss.NormalBorder()
+	case "hidden":
+		return lipgloss.HiddenBorder()
+	default:
+		return lipgloss.RoundedBorder()
+	}
+}
+
+// ApplyTheme resolves cfg onto the currently active styles (the built-in
+// defaults in styles.go, on the first call) and installs the result as the
+// active styles, both here and in internal/reporter (which keeps its own
+// copy to avoid an import cycle). A nil cfg leaves the current glyphs and
+// styles as they are, so it does not undo an earlier call's overrides.
+// NO_COLOR/PRISM_NO_COLOR are enforced regardless of cfg: plain=true strips
+// every style, leaving only the glyphs.
+func ApplyTheme(cfg *ThemeConfig) {
+	plain := noColorRequested()
+	if cfg == nil {
+		cfg = &ThemeConfig{}
+	}
+
+	passGlyph, passStyle = applyStatusOverrides(passGlyph, passStyle, cfg.Pass, plain)
+	failGlyph, failStyle = applyStatusOverrides(failGlyph, failStyle, cfg.Fail, plain)
+	skipGlyph, skipStyle = applyStatusOverrides(skipGlyph, skipStyle, cfg.Skip, plain)
+	flakyGlyph, flakyStyle = applyStatusOverrides(flakyGlyph, flakyStyle, cfg.Flaky, plain)
+	runningGlyph, runningStyle = applyStatusOverrides(runningGlyph, runningStyle, cfg.Running, plain)
+
+	packageStyle = applyTextOverrides(packageStyle, cfg.Package, plain)
+	testNameStyle = applyTextOverrides(testNameStyle, cfg.TestName, plain)
+	durationStyle = applyTextOverrides(durationStyle, cfg.Duration, plain)
+	outputStyle = applyTextOverrides(outputStyle, cfg.Output, plain)
+
+	border := borderFromName(cfg.Border)
+	if plain {
+		border = lipgloss.HiddenBorder()
+	}
+	pkgTableStyle = pkgTableStyle.Border(border)
+
+	reporter.SetTheme(reporter.Theme{
+		Pass:     reporter.StatusRender{Glyph: passGlyph, Style: passStyle},
+		Fail:     reporter.StatusRender{Glyph: failGlyph, Style: failStyle},
+		Skip:     reporter.StatusRender{Glyph: skipGlyph, Style: skipStyle},
+		Flaky:    reporter.StatusRender{Glyph: flakyGlyph, Style: flakyStyle},
+		Running:  reporter.StatusRender{Glyph: runningGlyph, Style: runningStyle},
+		Package:  packageStyle,
+		TestName: testNameStyle,
+		Duration: durationStyle,
+		Output:   outputStyle,
+		Border:   border,
+	})
+}
@@ -0,0 +1,31 @@
+package internal
+
+import "testing"
+
+// TestApplyThemeOverridesGlyph confirms a config-supplied glyph overrides
+// the built-in default for a status's rendering.
+func TestApplyThemeOverridesGlyph(t *testing.T) {
+	defer ApplyTheme(nil) // re-syncs internal/reporter; the glyph override stays in place
+
+	glyph := "PASS"
+	ApplyTheme(&ThemeConfig{Pass: &StatusStyleConfig{Glyph: &glyph}})
+
+	if passGlyph != "PASS" {
+		t.Errorf("passGlyph = %q, want %q", passGlyph, "PASS")
+	}
+}
+
+// TestApplyThemeNoColorStripsStyle confirms NO_COLOR forces plain styles
+// even when a config file requests colors.
+func TestApplyThemeNoColorStripsStyle(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	defer ApplyTheme(nil)
+
+	color := "#ff0000"
+	ApplyTheme(&ThemeConfig{Fail: &StatusStyleConfig{Color: &color}})
+
+	const probe = "x"
+	if got := failStyle.Render(probe); got != probe {
+		t.Errorf("failStyle.Render(%q) = %q, want unstyled %q under NO_COLOR", probe, got, probe)
+	}
+}
@@ -2,13 +2,11 @@ package internal
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/charmbracelet/lipgloss/v2"
-	"github.com/charmbracelet/lipgloss/v2/table"
+	"charm.land/lipgloss/v2"
 )
 
 // --- Constants for Test Statuses ---
@@ -17,14 +15,37 @@ const (
 	StatusPass    Status = "pass"
 	StatusFail    Status = "fail"
 	StatusSkip    Status = "skip"
+	StatusFlaky   Status = "flaky" // Failed on an earlier attempt, then passed under --rerun-fails
 	StatusOutput  Status = "output"
 	StatusRunning Status = "running" // Internal status for tests currently executing
+
+	// StatusBuildOutput and StatusBuildFail are `go test -json` actions for
+	// a package's own build failure, keyed by TestEvent.ImportPath rather
+	// than Package - see processEvent.
+	StatusBuildOutput Status = "build-output"
+	StatusBuildFail   Status = "build-fail"
 )
 
 var GlobalConfig Config
 
 type Config struct {
-	Verbose bool
+	Verbose                bool
+	OnlyFails              bool
+	JunitFile              string
+	JunitTestcaseClassname string
+	Format                 string
+	RerunFails             int
+	RerunFailsMaxFailures  int
+	RerunFailsReport       string
+	LogLevel               string
+	LogJSON                bool
+	ConfigPath             string
+	Watch                  bool
+	// FormatExplicit is true when the user passed --format themselves,
+	// rather than it holding its "pretty" default. It lets an explicit
+	// --format win over --watch's TTY-detected default instead of being
+	// silently discarded in favor of the TUI.
+	FormatExplicit bool
 }
 
 type Status string
@@ -34,13 +55,15 @@ func (s Status) String() string {
 	var style lipgloss.Style
 	switch s {
 	case StatusPass:
-		icon, style = "✓", passStyle
+		icon, style = passGlyph, passStyle
 	case StatusFail:
-		icon, style = "✗", failStyle
+		icon, style = failGlyph, failStyle
 	case StatusSkip:
-		icon, style = "⊝", skipStyle
+		icon, style = skipGlyph, skipStyle
+	case StatusFlaky:
+		icon, style = flakyGlyph, flakyStyle
 	default:
-		icon, style = "◌", lipgloss.NewStyle().Foreground(lipgloss.Color("#B0B0B0"))
+		icon, style = runningGlyph, runningStyle
 	}
 
 	return style.Render(fmt.Sprintf("%v %v", icon, strings.ToUpper(string(s))))
@@ -54,6 +77,12 @@ type TestEvent struct {
 	Test    string    `json:"Test"` // Empty for package-level events
 	Output  string    `json:"Output"`
 	Elapsed float64   `json:"Elapsed"` // In seconds
+
+	// ImportPath carries the package instead of Package for a build's own
+	// "build-output"/"build-fail" actions (the compiler diagnostic emitted
+	// ahead of a package's regular pass/fail/skip event), suffixed with
+	// ".test"; see packageFromImportPath.
+	ImportPath string `json:"ImportPath"`
 }
 
 // --- TestResult (Internal aggregated representation for a single test) ---
@@ -63,17 +92,28 @@ type TestResult struct {
 	Status   Status // StatusPass, StatusFail, StatusSkip, StatusRunning
 	Duration time.Duration
 	Output   []string // Raw output from the test
+
+	// Attempts counts how many times this test was run, including the
+	// initial pass. It's 1 unless --rerun-fails caused it to be retried.
+	Attempts int
+	// FlakyPassed is true if this test failed on its first attempt but
+	// later passed under --rerun-fails. Status is then StatusFlaky rather
+	// than StatusPass.
+	FlakyPassed bool
 }
 
 // --- PackageResults (Aggregated results for a single package) ---
 type PackageResults struct {
-	Name     string
-	Tests    []TestResult
-	Status   Status // Derived: StatusPass, StatusFail, StatusSkip
-	Total    int
-	Passed   int
-	Failed   int
-	Skipped  int
+	Name    string
+	Tests   []TestResult
+	Status  Status // Derived: StatusPass, StatusFail, StatusSkip
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+	// Flaky counts tests in this package that failed on an earlier attempt
+	// but passed under --rerun-fails, counted separately from Passed.
+	Flaky    int
 	Duration time.Duration // Sum of individual test durations in the package
 }
 
@@ -84,158 +124,22 @@ type TestSummary struct {
 	Passed     int
 	Failed     int
 	Skipped    int
-	Total      int
+	// Flaky counts tests that failed on an earlier attempt but passed under
+	// --rerun-fails, counted separately from Passed so a run that only went
+	// green on retry doesn't look identical to one that passed outright.
+	Flaky int
+	Total int
+
+	// PackageEvents buffers raw output from package-scoped events
+	// (event.Test == ""), keyed by package. This is where compile errors,
+	// TestMain panics, and other output not attributable to a single test
+	// end up.
+	PackageEvents map[string][]string
+	// PackageFinal records each package's final action from `go test -json`
+	// (its own pass/fail/skip event, distinct from any individual test's).
+	PackageFinal map[string]Status
 }
 
 func (summary *TestSummary) String() string {
 	return ""
 }
-
-// displayResults collects all rendered strings and returns them as a single output string.
-func displayResults(overallSummary *TestSummary) {
-	var renderBlocks []string
-
-	groupedByPackage := make(map[string]*PackageResults)
-	for _, testResult := range overallSummary.Results {
-		pkgName := testResult.Package
-		if _, ok := groupedByPackage[pkgName]; !ok {
-			groupedByPackage[pkgName] = &PackageResults{
-				Name:   pkgName,
-				Tests:  []TestResult{},
-				Status: StatusPass,
-			}
-		}
-		pkgResults := groupedByPackage[pkgName]
-		pkgResults.Tests = append(pkgResults.Tests, testResult)
-		pkgResults.Total++
-		pkgResults.Duration += testResult.Duration
-
-		switch testResult.Status {
-		case StatusPass:
-			pkgResults.Passed++
-		case StatusFail:
-			pkgResults.Failed++
-			pkgResults.Status = StatusFail
-		case StatusSkip:
-			pkgResults.Skipped++
-			if pkgResults.Status == StatusPass && pkgResults.Skipped == pkgResults.Total {
-				pkgResults.Status = StatusSkip
-			}
-		}
-	}
-
-	packageNames := make([]string, 0, len(groupedByPackage))
-	for pkgName := range groupedByPackage {
-		packageNames = append(packageNames, pkgName)
-	}
-	sort.Strings(packageNames)
-
-	for _, pkgName := range packageNames {
-		pkgResults := groupedByPackage[pkgName]
-		renderBlocks = append(renderBlocks, displayPackageBlock(pkgResults))
-	}
-
-	// Overall summary
-	if len(groupedByPackage) > 1 {
-		renderBlocks = append(renderBlocks, displayOverallSummary(overallSummary))
-	}
-
-	// Join all blocks with two newlines for separation (a blank line between them)
-	fmt.Println(AppOverallOutputStyle.Render(lipgloss.JoinVertical(lipgloss.Left, renderBlocks...)))
-}
-
-// displayPackageBlock builds and returns the display string for a single package.
-// It returns a string without a trailing newline.
-func displayPackageBlock(pkgResults *PackageResults) string {
-	pkgHeader := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		pkgResults.Status.String(),
-		" ",
-		packageStyle.Render(pkgResults.Name),
-		" ",
-		durationStyle.Render(fmt.Sprintf("(%v)", pkgResults.Duration)),
-	)
-
-	pkgHeader = lipgloss.JoinVertical(lipgloss.Left, pkgHeader,
-		fmt.Sprintf(
-			"%d total • %s • %s • %s",
-			pkgResults.Total,
-			passStyle.Render(fmt.Sprintf("%d passed", pkgResults.Passed)),
-			failStyle.Render(fmt.Sprintf("%d failed", pkgResults.Failed)),
-			skipStyle.Render(fmt.Sprintf("%d skipped", pkgResults.Skipped)),
-		),
-	)
-
-	sort.Slice(pkgResults.Tests, func(i, j int) bool {
-		statusOrder := map[Status]int{
-			StatusFail:    3,
-			StatusSkip:    2,
-			StatusPass:    1,
-			StatusRunning: 0,
-		}
-		orderI := statusOrder[pkgResults.Tests[i].Status]
-		orderJ := statusOrder[pkgResults.Tests[j].Status]
-
-		if orderI != orderJ {
-			return orderI < orderJ
-		}
-		nameI := strings.TrimPrefix(pkgResults.Tests[i].Name, "Test")
-		nameJ := strings.TrimPrefix(pkgResults.Tests[j].Name, "Test")
-		return nameI < nameJ
-	})
-
-	t := table.New().
-		Border(lipgloss.HiddenBorder()).
-		Headers("RESULT", "DUR", "TEST").
-		Rows(generateTestRows(pkgResults.Tests)...)
-
-	tableStr := t.Render()
-
-	separatorLine := packageSeparatorStyle.Render(strings.Repeat("─", max(lipgloss.Width(tableStr), lipgloss.Width(pkgHeader))))
-
-	return lipgloss.JoinVertical(lipgloss.Left,
-		pkgHeader,
-		separatorLine,
-		pkgTableStyle.Render(tableStr),
-	)
-}
-
-// generateTestRows creates the rows for the lipgloss table.
-// This helper function remains, returning [][]string data.
-func generateTestRows(tests []TestResult) [][]string {
-	rows := make([][]string, 0) // Initialize with 0 capacity as output lines are dynamic
-	for _, result := range tests {
-		displayTestName := strings.TrimPrefix(result.Name, "Test")
-
-		row := []string{
-			result.Status.String(),
-			durationStyle.Render(fmt.Sprintf("%v", result.Duration)),
-			testNameStyle.Render(displayTestName),
-		}
-		rows = append(rows, row)
-
-		if result.Status == StatusFail && len(result.Output) > 0 && GlobalConfig.Verbose {
-			for _, line := range result.Output {
-				if strings.TrimSpace(line) != "" && !(strings.HasPrefix(line, "===") || strings.HasPrefix(line, "---")) {
-					outputRow := []string{"", "", outputStyle.Render(line)}
-					rows = append(rows, outputRow)
-				}
-			}
-		}
-	}
-	return rows
-}
-
-// displayOverallSummary builds and returns the display string for the overall summary.
-func displayOverallSummary(summary *TestSummary) string {
-	out := "Overall Test Results\n"
-	out += fmt.Sprintf(
-		"%d total • %s • %s • %s",
-		summary.Total,
-		passStyle.Render(fmt.Sprintf("%d passed", summary.Passed)),
-		failStyle.Render(fmt.Sprintf("%d failed", summary.Failed)),
-		skipStyle.Render(fmt.Sprintf("%d skipped", summary.Skipped)),
-	)
-
-	return pkgTableStyle.Padding(1).Render(out)
-}